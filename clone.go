@@ -0,0 +1,94 @@
+package query
+
+import "slices"
+
+// Clone retorna uma cópia independente do builder: alterações na cópia não afetam o original e vice-versa.
+//
+// As configurações (span, métricas, Hook, política de redação) são compartilhadas, enquanto condições, colunas,
+// JOINs, ordenação e paginação são copiados. O erro retornado por Err não é copiado.
+//
+// A cópia é sempre mutável, mesmo quando o original foi criado com Immutable, permitindo entregá-la a funções que
+// alteram o builder recebido em vez de retorná-lo.
+//
+// Exemplo de uso:
+//
+//	base := query.NewQueryBuilder().From("users").WhereAnd(query.Where{Column: "active", Type: "=", Val: true})
+//
+//	list := base.Clone().PaginationPaged(1, 20)
+//	export := base.Clone().Select("id", "email")
+func (q *QueryBuilder) Clone() *QueryBuilder {
+	clone := &QueryBuilder{
+		config: q.config,
+
+		otelSpan:  q.otelSpan,
+		redaction: q.redaction,
+		metrics:   q.metrics,
+		hook:      q.hook,
+
+		from:      q.from,
+		table:     q.table,
+		alias:     q.alias,
+		selects:   slices.Clone(q.selects),
+		values:    slices.Clone(q.values),
+		joins:     slices.Clone(q.joins),
+		wheresAnd: cloneWhereGroups(q.wheresAnd),
+		wheresOr:  cloneWhereGroups(q.wheresOr),
+		groupBy:   slices.Clone(q.groupBy),
+		orderBys:  slices.Clone(q.orderBys),
+
+		withoutTenant: q.withoutTenant,
+		deleted:       q.deleted,
+		version:       q.version,
+	}
+	clone.config.immutable = false
+
+	if q.limit != nil {
+		limit := *q.limit
+		clone.limit = &limit
+	}
+	if q.offset != nil {
+		offset := *q.offset
+		clone.offset = &offset
+	}
+
+	return clone
+}
+
+// builder retorna o próprio builder ou, no modo Immutable, uma cópia imutável que recebe a alteração.
+func (q *QueryBuilder) builder() *QueryBuilder {
+	if !q.config.immutable {
+		return q
+	}
+
+	clone := q.Clone()
+	clone.config.immutable = true
+
+	return clone
+}
+
+func cloneWhereGroups(groups [][]Where) [][]Where {
+	if groups == nil {
+		return nil
+	}
+
+	clone := make([][]Where, 0, len(groups))
+	for _, item := range groups {
+		clone = append(clone, cloneWheres(item))
+	}
+
+	return clone
+}
+func cloneWheres(wheres []Where) []Where {
+	if wheres == nil {
+		return nil
+	}
+
+	clone := make([]Where, 0, len(wheres))
+	for _, item := range wheres {
+		item.And = cloneWheres(item.And)
+		item.Or = cloneWheres(item.Or)
+		clone = append(clone, item)
+	}
+
+	return clone
+}
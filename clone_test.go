@@ -0,0 +1,74 @@
+package query
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClone(t *testing.T) {
+	t.Run("Validate Independent Branches", func(t *testing.T) {
+		base := NewQueryBuilder().From("users").WhereAnd(Where{Or: []Where{{Column: "status", Type: "=", Val: "active"}}}).Limit(10)
+
+		list := base.Clone().WhereAnd(Where{Column: "age", Type: ">", Val: 18}).OrderBy(OrderBy{Column: "name"}).Limit(20)
+		export := base.Clone().Select("id", "email").GroupBy("id")
+
+		// Alterações no grupo aninhado da cópia não podem vazar para o original
+		list.wheresAnd[0][0].Or[0].Val = "blocked"
+
+		sql, params := base.ToSelectSql()
+		assert.Equal(t, `SELECT * FROM "users" WHERE ((status = $1)) LIMIT 10`, sql)
+		assert.Equal(t, []interface{}{"active"}, params)
+
+		sql, params = list.ToSelectSql()
+		assert.Equal(t, `SELECT * FROM "users" WHERE ((status = $1)) AND (age > $2) ORDER BY name LIMIT 20`, sql)
+		assert.Equal(t, []interface{}{"blocked", 18}, params)
+
+		sql, _ = export.ToSelectSql()
+		assert.Equal(t, `SELECT id, email FROM "users" WHERE ((status = $1)) GROUP BY id LIMIT 10`, sql)
+	})
+
+	t.Run("Validate Immutable", func(t *testing.T) {
+		base := NewQueryBuilder(Immutable()).From("users").WhereAnd(Where{Column: "active", Type: "=", Val: true})
+
+		list := base.PaginationPaged(2, 20)
+		export := base.Select("id").GroupBy("id").GroupBy("email")
+
+		sql, _ := base.ToSelectSql()
+		assert.Equal(t, `SELECT * FROM "users" WHERE (active = $1)`, sql)
+
+		sql, _ = list.ToSelectSql()
+		assert.Equal(t, `SELECT * FROM "users" WHERE (active = $1) LIMIT 20 OFFSET 20`, sql)
+
+		sql, _ = export.ToSelectSql()
+		assert.Equal(t, `SELECT id FROM "users" WHERE (active = $1) GROUP BY id, email`, sql)
+
+		// Clone retorna uma cópia mutável
+		clone := base.Clone()
+		clone.WhereAnd(Where{Column: "age", Type: ">", Val: 18})
+
+		sql, _ = clone.ToSelectSql()
+		assert.Equal(t, `SELECT * FROM "users" WHERE (active = $1) AND (age > $2)`, sql)
+	})
+
+	t.Run("Validate Immutable Concurrency", func(t *testing.T) {
+		base := NewQueryBuilder(Immutable()).From("users").WhereAnd(Where{Column: "active", Type: "=", Val: true})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				sql, params := base.WhereAnd(Where{Column: "id", Type: "=", Val: i}).ToSelectSql()
+				assert.Equal(t, `SELECT * FROM "users" WHERE (active = $1) AND (id = $2)`, sql)
+				assert.Equal(t, []interface{}{true, i}, params)
+			}(i)
+		}
+		wg.Wait()
+
+		sql, _ := base.ToSelectSql()
+		assert.Equal(t, `SELECT * FROM "users" WHERE (active = $1)`, sql)
+	})
+}
@@ -6,6 +6,12 @@ import (
 
 type Config struct {
 	parseWhere bool
+	emptyIn    EmptyInBehavior
+	immutable  bool
+	tenant     *tenantScope
+	softDelete map[string]string
+	lockColumn string
+	validate   bool
 }
 type QueryBuilderConfig func(*QueryBuilder)
 
@@ -14,8 +20,38 @@ func ParseWhere(parse bool) QueryBuilderConfig {
 		q.config.parseWhere = parse
 	}
 }
+
+// EmptyIn define como condições IN / NOT IN com slice vazio são tratadas.
+//
+// Por padrão (EmptyInConstant) `col IN ()` é renderizado como FALSE e `col NOT IN ()` como TRUE.
+// Com EmptyInError o mesmo predicado constante é gerado, mas o erro fica disponível em Err().
+func EmptyIn(behavior EmptyInBehavior) QueryBuilderConfig {
+	return func(q *QueryBuilder) {
+		q.config.emptyIn = behavior
+	}
+}
 func SetOtelSpan(span trace.Span) QueryBuilderConfig {
 	return func(q *QueryBuilder) {
 		q.otelSpan = span
 	}
 }
+
+// Immutable faz com que cada método do builder (From, Select, WhereAnd, OrderBy, ...) retorne um novo builder com a
+// alteração, mantendo o original intacto. Assim uma query base pode ser compartilhada entre goroutines e ramificada
+// sem que as condições de um ramo apareçam no outro.
+//
+// Como o builder recebido não é alterado, o retorno de cada método deve ser utilizado, inclusive o das funções que
+// recebem o builder, como Schema.ParseURL do pacote filter. Funções que modificam o builder em vez de retorná-lo
+// devem receber uma cópia mutável, obtida com Clone.
+//
+// Exemplo de uso:
+//
+//	base := query.NewQueryBuilder(query.Immutable()).From("users").WhereAnd(query.Where{Column: "active", Type: "=", Val: true})
+//
+//	list := base.PaginationPaged(1, 20)
+//	export := base.Select("id", "email")
+func Immutable() QueryBuilderConfig {
+	return func(q *QueryBuilder) {
+		q.config.immutable = true
+	}
+}
@@ -0,0 +1,217 @@
+package query
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DebugSQL retorna o SELECT do builder com os parâmetros interpolados (veja Interpolate), aplicando a política de
+// Redact nos valores. A renderização não registra span, métricas, Hook nem Err, e os valores de ParseWhere(false)
+// também passam pela redação.
+//
+// ATENÇÃO: o resultado é somente para logs e para copiar no psql durante uma investigação. Nunca execute o SQL
+// retornado: utilize sempre ToSelectSql com os parâmetros separados.
+//
+// Exemplo de uso:
+//
+//	fmt.Println(qb.DebugSQL())
+//	// SELECT * FROM "users" WHERE (name = 'O''Brien' AND age > 18)
+func (q *QueryBuilder) DebugSQL() string {
+	silent := q.Clone()
+	silent.otelSpan, silent.metrics, silent.hook = nil, nil, nil
+	silent.config.parseWhere = true
+	silent.config.validate = false
+
+	stmt, _ := silent.toSelectSql()
+
+	return Interpolate(stmt.SQL, q.redactArgs(stmt.Args, stmt.Columns))
+}
+
+// Interpolate substitui os placeholders `$n` de sql pelos valores de args, codificados como literais do Postgres.
+//
+// ATENÇÃO: o resultado é somente para logs e para copiar no psql. Nunca execute o SQL retornado: a interpolação não
+// substitui os parâmetros do driver na prevenção de SQL injection.
+//
+// Placeholders dentro de strings, identificadores entre aspas, strings com `$tag$` e comentários são preservados,
+// assim como placeholders sem valor correspondente em args. Os valores são codificados da seguinte forma:
+//
+//	nil                 NULL
+//	bool                TRUE / FALSE
+//	inteiros e floats   123, 15000.5, 'NaN'::float8
+//	string              'O''Brien'
+//	[]byte              '\x0102'::bytea
+//	time.Time           '2025-01-31 10:00:00Z'::timestamptz
+//	slices              ARRAY['a', 'b']
+//	driver.Valuer       o literal do valor retornado por Value()
+//
+// Demais tipos são codificados como string com fmt.Sprint.
+func Interpolate(sql string, args []any) string {
+	var result strings.Builder
+
+	for i := 0; i < len(sql); {
+		end := skipLiteral(sql, i)
+		if end > i {
+			result.WriteString(sql[i:end])
+			i = end
+			continue
+		}
+
+		if sql[i] == '$' && i+1 < len(sql) && isDigit(sql[i+1]) {
+			end = i + 1
+			for end < len(sql) && isDigit(sql[end]) {
+				end++
+			}
+
+			if n, err := strconv.Atoi(sql[i+1 : end]); err == nil && n >= 1 && n <= len(args) {
+				value := literal(args[n-1])
+				// Evita que `x-$1` com um valor negativo vire o comentário `x--1`
+				if strings.HasPrefix(value, "-") && i > 0 && sql[i-1] == '-' {
+					result.WriteByte(' ')
+				}
+				result.WriteString(value)
+				i = end
+				continue
+			}
+		}
+
+		result.WriteByte(sql[i])
+		i++
+	}
+
+	return result.String()
+}
+
+// skipLiteral retorna a posição final da string, identificador, string com `$tag$` ou comentário iniciado em i, ou
+// o próprio i quando não há nenhum deles nessa posição.
+func skipLiteral(sql string, i int) int {
+	switch {
+	case sql[i] == '\'' || sql[i] == '"':
+		quote := sql[i]
+		for j := i + 1; j < len(sql); j++ {
+			if sql[j] != quote {
+				continue
+			}
+			// Aspas duplicadas representam uma aspa dentro do valor
+			if j+1 < len(sql) && sql[j+1] == quote {
+				j++
+				continue
+			}
+			return j + 1
+		}
+		return len(sql)
+	case strings.HasPrefix(sql[i:], "--"):
+		if end := strings.IndexByte(sql[i:], '\n'); end != -1 {
+			return i + end + 1
+		}
+		return len(sql)
+	case strings.HasPrefix(sql[i:], "/*"):
+		if end := strings.Index(sql[i+2:], "*/"); end != -1 {
+			return i + 2 + end + 2
+		}
+		return len(sql)
+	case sql[i] == '$':
+		// $tag$ ... $tag$, onde a tag pode ser vazia; $1 é um placeholder
+		end := i + 1
+		for end < len(sql) && (sql[end] == '_' || isLetter(sql[end]) || end > i+1 && isDigit(sql[end])) {
+			end++
+		}
+		if end >= len(sql) || sql[end] != '$' {
+			return i
+		}
+
+		tag := sql[i : end+1]
+		if close := strings.Index(sql[end+1:], tag); close != -1 {
+			return end + 1 + close + len(tag)
+		}
+		return len(sql)
+	default:
+		return i
+	}
+}
+
+// literal codifica value como um literal do Postgres.
+func literal(value any) string {
+	if valuer, ok := value.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		if err != nil {
+			return quoteString(fmt.Sprint(value))
+		}
+		value = v
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	case string:
+		return quoteString(v)
+	case []byte:
+		return fmt.Sprintf(`'\x%s'::bytea`, hex.EncodeToString(v))
+	case time.Time:
+		return fmt.Sprintf("'%s'::timestamptz", v.Format("2006-01-02 15:04:05.999999999Z07:00"))
+	case float32:
+		return formatFloat(float64(v), 32)
+	case float64:
+		return formatFloat(v, 64)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(v)
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return "NULL"
+		}
+		return literal(rv.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		items := make([]string, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			items = append(items, literal(rv.Index(i).Interface()))
+		}
+		return fmt.Sprintf("ARRAY[%s]", strings.Join(items, ", "))
+	case reflect.Bool:
+		return literal(rv.Bool())
+	case reflect.String:
+		return quoteString(rv.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return formatFloat(rv.Float(), 64)
+	}
+
+	return quoteString(fmt.Sprint(value))
+}
+func quoteString(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+func formatFloat(value float64, bitSize int) string {
+	switch {
+	case math.IsNaN(value):
+		return "'NaN'::float8"
+	case math.IsInf(value, 1):
+		return "'Infinity'::float8"
+	case math.IsInf(value, -1):
+		return "'-Infinity'::float8"
+	}
+
+	return strconv.FormatFloat(value, 'g', -1, bitSize)
+}
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
@@ -0,0 +1,114 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"testing"
+	"time"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestInterpolate(t *testing.T) {
+	name := "Mark"
+
+	data := []struct {
+		title  string
+		sql    string
+		args   []any
+		result string
+	}{
+		{
+			title:  "Test Scalars",
+			sql:    `SELECT * FROM "users" WHERE (name = $1 AND age > $2 AND salary = $3 AND active = $4 AND deleted_at = $5)`,
+			args:   []any{"O'Brien", 18, 15000.5, true, nil},
+			result: `SELECT * FROM "users" WHERE (name = 'O''Brien' AND age > 18 AND salary = 15000.5 AND active = TRUE AND deleted_at = NULL)`,
+		},
+		{
+			title:  "Test Special Types",
+			sql:    `SELECT $1, $2, $3, $4, $5, $6, $7`,
+			args:   []any{[]byte{1, 2}, time.Date(2025, 1, 31, 10, 0, 0, 0, time.UTC), []string{"a", "b"}, math.NaN(), &name, sql.NullString{}, sql.NullInt64{Int64: 7, Valid: true}},
+			result: `SELECT '\x0102'::bytea, '2025-01-31 10:00:00Z'::timestamptz, ARRAY['a', 'b'], 'NaN'::float8, 'Mark', NULL, 7`,
+		},
+		{
+			title:  "Test Placeholders Order",
+			sql:    `SELECT * FROM t WHERE a = $10 AND b = $1 AND c = $11`,
+			args:   []any{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			result: `SELECT * FROM t WHERE a = 10 AND b = 1 AND c = $11`,
+		},
+		{
+			title:  "Test Preserved Literals",
+			sql:    `SELECT '$1', "col$1", $$ $1 $$, $tag$ it's $1 $tag$ /* $1 */, $1 -- $1` + "\n" + `FROM t`,
+			args:   []any{"x"},
+			result: `SELECT '$1', "col$1", $$ $1 $$, $tag$ it's $1 $tag$ /* $1 */, 'x' -- $1` + "\n" + `FROM t`,
+		},
+		{
+			title:  "Test Negative After Minus",
+			sql:    `SELECT 10-$1, $1`,
+			args:   []any{-5},
+			result: `SELECT 10- -5, -5`,
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.title, func(t *testing.T) {
+			assert.Equal(t, item.result, Interpolate(item.sql, item.args))
+		})
+	}
+
+	t.Run("Validate DebugSQL", func(t *testing.T) {
+		qb := NewQueryBuilder().From("users").WhereAnd(
+			Where{Column: "name", Type: "=", Val: "O'Brien"},
+			Where{Column: "status", Type: "in", Val: []string{"a", "b"}},
+		)
+
+		debug := qb.DebugSQL()
+
+		assert.Equal(t, `SELECT * FROM "users" WHERE (name = 'O''Brien' AND status IN ('a', 'b'))`, debug)
+
+		_, err := pg_query.Parse(debug)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Validate DebugSQL Without Side Effects", func(t *testing.T) {
+		spanRecorder := tracetest.NewSpanRecorder()
+		provider := trace.NewTracerProvider(trace.WithSpanProcessor(spanRecorder))
+		_, span := provider.Tracer("test-tracer").Start(context.Background(), "debug")
+
+		events := 0
+		qb := NewQueryBuilder(SetOtelSpan(span), SetHook(HookFunc(func(ctx context.Context, event Event) { events++ })), EmptyIn(EmptyInError)).
+			From("users").
+			WhereAnd(Where{Column: "status", Type: "in", Val: []string{}})
+
+		assert.Equal(t, `SELECT * FROM "users" WHERE (FALSE)`, qb.DebugSQL())
+		span.End()
+
+		spans := spanRecorder.Ended()
+		require.Len(t, spans, 1)
+		// Somente o atributo registrado por From
+		assert.Equal(t, []attribute.KeyValue{attribute.String("db.collection.name", "users")}, spans[0].Attributes())
+		assert.Empty(t, spans[0].Events())
+		assert.Zero(t, events)
+		assert.NoError(t, qb.Err())
+	})
+
+	t.Run("Validate DebugSQL Redaction", func(t *testing.T) {
+		for _, parse := range []bool{true, false} {
+			qb := NewQueryBuilder(ParseWhere(parse), Redact(RedactionPolicy{Allow: []string{"age"}, Deny: []string{"password"}, Redactor: Mask(2)})).
+				From("users").
+				WhereAnd(
+					Where{Column: "age", Type: ">", Val: 18},
+					Where{Column: "cpf", Type: "=", Val: "123.456.789-10"},
+					Where{Column: "password", Type: "=", Val: "secret"},
+				)
+
+			assert.Equal(t, `SELECT * FROM "users" WHERE (age > 18 AND cpf = '************10' AND password = '[REDACTED]')`, qb.DebugSQL())
+		}
+	})
+}
@@ -0,0 +1,235 @@
+package executor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"go.opentelemetry.io/otel/trace"
+
+	query "github.com/MMortari/go-query-builder"
+)
+
+// Querier é o subconjunto de database/sql utilizado pelo Executor.
+//
+// É satisfeito por *sql.DB, *sql.Tx e *sql.Conn, permitindo executar as mesmas queries dentro ou fora de uma transação.
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type Executor struct {
+	db Querier
+
+	tracer        trace.Tracer
+	metrics       *metrics
+	serverAddress string
+	serverPort    int
+}
+
+// New cria um Executor que roda as queries geradas pelo QueryBuilder no Querier informado.
+//
+// Exemplo de uso:
+//
+//	exec := executor.New(db)
+//
+//	tx, _ := db.BeginTx(ctx, nil)
+//	txExec := executor.New(tx)
+func New(db Querier, opts ...Option) *Executor {
+	e := &Executor{db: db}
+
+	for _, item := range opts {
+		item(e)
+	}
+
+	return e
+}
+
+// Query executa ToSelectSql e retorna as linhas sem processamento.
+func (e *Executor) Query(ctx context.Context, qb *query.QueryBuilder) (*sql.Rows, error) {
+	rows, err := e.selectRows(ctx, qb)
+	if err != nil {
+		return nil, err
+	}
+
+	// As linhas são lidas por quem chamou, então a observação é encerrada sem o número de linhas
+	rows.observation.End(-1, nil)
+
+	return rows.Rows, nil
+}
+
+// Select executa ToSelectSql e adiciona cada linha retornada ao slice apontado por dest.
+//
+// Os elementos podem ser structs mapeadas pela tag `db` (veja ScanAll) ou tipos simples quando a query retorna
+// uma única coluna.
+//
+// Exemplo de uso:
+//
+//	var users []User
+//	err := exec.Select(ctx, query.NewQueryBuilder().From("users").Select("id", "name"), &users)
+func (e *Executor) Select(ctx context.Context, qb *query.QueryBuilder, dest any) error {
+	if _, err := sliceOf(dest); err != nil {
+		return err
+	}
+
+	rows, err := e.selectRows(ctx, qb)
+	if err != nil {
+		return err
+	}
+
+	return rows.close(ScanAll(rows, dest))
+}
+
+// Get executa ToSelectSql e lê a primeira linha retornada em dest.
+//
+// Quando dest é um único ponteiro para struct, as colunas são mapeadas pela tag `db` (veja ScanAll). Caso contrário,
+// dest é lido da mesma forma que (*sql.Row).Scan.
+//
+// Retorna sql.ErrNoRows quando a query não retorna nenhuma linha.
+func (e *Executor) Get(ctx context.Context, qb *query.QueryBuilder, dest ...any) error {
+	if len(dest) == 1 && IsStructPointer(dest[0]) {
+		rows, err := e.selectRows(ctx, qb)
+		if err != nil {
+			return err
+		}
+
+		return rows.close(ScanOne(rows, dest[0]))
+	}
+
+	stmt, err := qb.BuildSelect()
+	if err != nil {
+		return err
+	}
+
+	return e.queryRow(ctx, qb, stmt, dest...)
+}
+
+// Count executa ToSelectTotalSql e retorna o total de registros.
+func (e *Executor) Count(ctx context.Context, qb *query.QueryBuilder) (total int64, err error) {
+	stmt, err := qb.BuildSelectTotal()
+	if err != nil {
+		return 0, err
+	}
+
+	err = e.queryRow(ctx, qb, stmt, &total)
+
+	return total, err
+}
+
+// Exec executa ToUpdateQuery e retorna o resultado do banco.
+//
+// Com query.OptimisticLock e a versão informada em ExpectVersion, retorna query.ErrStaleVersion quando nenhum
+// registro é alterado.
+func (e *Executor) Exec(ctx context.Context, qb *query.QueryBuilder) (sql.Result, error) {
+	stmt, err := qb.BuildUpdate()
+	if err != nil {
+		return nil, err
+	}
+
+	return e.exec(ctx, qb, stmt)
+}
+
+// Delete executa ToDeleteQuery e retorna o resultado do banco. Quando a tabela foi configurada com
+// query.SoftDelete, a query executada é o UPDATE de remoção lógica. Assim como Exec, retorna query.ErrStaleVersion
+// quando a versão informada em ExpectVersion não é mais a atual.
+func (e *Executor) Delete(ctx context.Context, qb *query.QueryBuilder) (sql.Result, error) {
+	stmt, err := qb.BuildDelete()
+	if err != nil {
+		return nil, err
+	}
+
+	return e.exec(ctx, qb, stmt)
+}
+
+func (e *Executor) selectRows(ctx context.Context, qb *query.QueryBuilder) (*tracedRows, error) {
+	stmt, err := qb.BuildSelect()
+	if err != nil {
+		return nil, err
+	}
+
+	return e.query(ctx, qb, stmt)
+}
+
+// query, queryRow e exec são os únicos pontos que executam SQL no banco. As linhas retornadas por query precisam
+// ser fechadas com close, que também encerra a observação (span e métricas).
+func (e *Executor) query(ctx context.Context, qb *query.QueryBuilder, stmt query.Statement) (*tracedRows, error) {
+	ctx, o := e.Observe(ctx, qb, stmt)
+
+	rows, err := e.db.QueryContext(ctx, stmt.SQL, stmt.Args...)
+	if err != nil {
+		o.End(-1, err)
+		return nil, err
+	}
+
+	return &tracedRows{Rows: rows, observation: o}, nil
+}
+func (e *Executor) queryRow(ctx context.Context, qb *query.QueryBuilder, stmt query.Statement, dest ...any) error {
+	ctx, o := e.Observe(ctx, qb, stmt)
+
+	err := e.db.QueryRowContext(ctx, stmt.SQL, stmt.Args...).Scan(dest...)
+
+	switch {
+	case err == nil:
+		o.End(1, nil)
+	case errors.Is(err, sql.ErrNoRows):
+		o.End(0, err)
+	default:
+		o.End(-1, err)
+	}
+
+	return err
+}
+func (e *Executor) exec(ctx context.Context, qb *query.QueryBuilder, stmt query.Statement) (sql.Result, error) {
+	ctx, o := e.Observe(ctx, qb, stmt)
+
+	result, err := e.db.ExecContext(ctx, stmt.SQL, stmt.Args...)
+	if err != nil {
+		o.EndExec(-1, err)
+		return result, err
+	}
+
+	affected, errAffected := result.RowsAffected()
+	if errAffected != nil {
+		o.EndExec(-1, nil)
+	} else {
+		o.EndExec(affected, nil)
+	}
+
+	if version, ok := qb.ExpectedVersion(); ok {
+		if errAffected != nil {
+			return result, errAffected
+		}
+		if affected == 0 {
+			return result, StaleVersion(qb, version)
+		}
+	}
+
+	return result, nil
+}
+
+// StaleVersion retorna query.ErrStaleVersion com a tabela e a versão esperada. É utilizado pelos executores quando
+// um UPDATE / DELETE com query.OptimisticLock não altera nenhum registro.
+func StaleVersion(qb *query.QueryBuilder, version any) error {
+	return fmt.Errorf("%w: %s version %v", query.ErrStaleVersion, qb.Table(), version)
+}
+
+func sliceOf(dest any) (reflect.Value, error) {
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Slice {
+		return reflect.Value{}, fmt.Errorf("executor: dest must be a non-nil pointer to a slice, got %T", dest)
+	}
+
+	return value.Elem(), nil
+}
+
+// IsStructPointer indica se dest é um ponteiro para uma struct mapeada campo a campo pela tag `db`, e não um valor
+// lido diretamente pelo driver, como *time.Time ou um sql.Scanner. É utilizado por Get para escolher entre ScanOne e
+// a leitura direta da linha.
+func IsStructPointer(dest any) bool {
+	t := reflect.TypeOf(dest)
+
+	return t != nil && t.Kind() == reflect.Pointer && isStruct(t.Elem())
+}
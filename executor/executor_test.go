@@ -0,0 +1,177 @@
+package executor
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	query "github.com/MMortari/go-query-builder"
+)
+
+func newMock(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, mock.ExpectationsWereMet())
+		db.Close()
+	})
+
+	return db, mock
+}
+
+func TestExecutor(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Test Select", func(t *testing.T) {
+		db, mock := newMock(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM "users" WHERE (age > $1)`)).
+			WithArgs(18).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+
+		var ids []int64
+		err := New(db).Select(ctx, query.NewQueryBuilder().From("users").Select("id").WhereAnd(query.Where{Column: "age", Type: ">", Val: 18}), &ids)
+
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1, 2}, ids)
+	})
+
+	t.Run("Test Select Invalid Dest", func(t *testing.T) {
+		db, _ := newMock(t)
+
+		var ids []int64
+		err := New(db).Select(ctx, query.NewQueryBuilder().From("users"), ids)
+
+		assert.ErrorContains(t, err, "pointer to a slice")
+	})
+
+	t.Run("Test Get", func(t *testing.T) {
+		db, mock := newMock(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name FROM "users" WHERE (id = $1)`)).
+			WithArgs(7).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(7, "Mark"))
+
+		var (
+			id   int64
+			name string
+		)
+		err := New(db).Get(ctx, query.NewQueryBuilder().From("users").Select("id", "name").WhereAnd(query.Where{Column: "id", Type: "=", Val: 7}), &id, &name)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), id)
+		assert.Equal(t, "Mark", name)
+	})
+
+	t.Run("Test Get No Rows", func(t *testing.T) {
+		db, mock := newMock(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM "users"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		var id int64
+		err := New(db).Get(ctx, query.NewQueryBuilder().From("users").Select("id"), &id)
+
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("Test Count", func(t *testing.T) {
+		db, mock := newMock(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total FROM "users" WHERE (active = $1)`)).
+			WithArgs(true).
+			WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(42))
+
+		total, err := New(db).Count(ctx, query.NewQueryBuilder().From("users").WhereAnd(query.Where{Column: "active", Type: "=", Val: true}))
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), total)
+	})
+
+	t.Run("Test Exec", func(t *testing.T) {
+		db, mock := newMock(t)
+
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET name = $1 WHERE (id = $2)`)).
+			WithArgs("Mark", 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		result, err := New(db).Exec(ctx, query.NewQueryBuilder().From("users").Values(query.Value{Column: "name", Val: "Mark"}).WhereAnd(query.Where{Column: "id", Type: "=", Val: 7}))
+		require.NoError(t, err)
+
+		affected, err := result.RowsAffected()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), affected)
+	})
+
+	t.Run("Test Delete", func(t *testing.T) {
+		db, mock := newMock(t)
+
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "users" WHERE (id = $1)`)).
+			WithArgs(7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "phones" SET deleted_at = $1 WHERE "phones".deleted_at IS NULL AND ((id = $2))`)).
+			WithArgs(sqlmock.AnyArg(), 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		where := query.Where{Column: "id", Type: "=", Val: 7}
+
+		_, err := New(db).Delete(ctx, query.NewQueryBuilder().From("users").WhereAnd(where))
+		require.NoError(t, err)
+
+		_, err = New(db).Delete(ctx, query.NewQueryBuilder(query.SoftDelete("phones", "deleted_at")).From("phones").WhereAnd(where))
+		require.NoError(t, err)
+	})
+
+	t.Run("Test Stale Version", func(t *testing.T) {
+		db, mock := newMock(t)
+
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET name = $1, version = version + 1 WHERE "users".version = $2 AND ((id = $3))`)).
+			WithArgs("Mark", 3, 7).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "users" WHERE "users".version = $1 AND ((id = $2))`)).
+			WithArgs(3, 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		qb := query.NewQueryBuilder(query.OptimisticLock("version")).From("users").WhereAnd(query.Where{Column: "id", Type: "=", Val: 7}).ExpectVersion(3)
+
+		_, err := New(db).Exec(ctx, qb.Clone().Values(query.Value{Column: "name", Val: "Mark"}))
+		assert.ErrorIs(t, err, query.ErrStaleVersion)
+		assert.EqualError(t, err, "query: stale version: users version 3")
+
+		_, err = New(db).Delete(ctx, qb)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Test Transaction", func(t *testing.T) {
+		db, mock := newMock(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET active = $1`)).
+			WithArgs(false).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectCommit()
+
+		tx, err := db.BeginTx(ctx, nil)
+		require.NoError(t, err)
+
+		_, err = New(tx).Exec(ctx, query.NewQueryBuilder().From("users").Values(query.Value{Column: "active", Val: false}))
+		require.NoError(t, err)
+		require.NoError(t, tx.Commit())
+	})
+
+	t.Run("Test Builder Error", func(t *testing.T) {
+		db, _ := newMock(t)
+
+		qb := query.NewQueryBuilder(query.EmptyIn(query.EmptyInError)).From("users").WhereAnd(query.Where{Column: "id", Type: "in", Val: []int{}})
+
+		_, err := New(db).Count(ctx, qb)
+
+		assert.ErrorIs(t, err, query.ErrEmptyIn)
+	})
+}
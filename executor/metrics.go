@@ -0,0 +1,66 @@
+package executor
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type metrics struct {
+	duration     metric.Float64Histogram
+	returnedRows metric.Int64Histogram
+}
+
+// WithMeter registra métricas de cada query executada no Meter informado, seguindo as convenções semânticas de banco
+// de dados do OpenTelemetry:
+//
+//   - db.client.operation.duration: tempo de execução, em segundos, com error.type quando a query falha.
+//   - db.client.response.returned_rows: número de linhas retornadas, quando conhecido.
+//
+// As métricas recebem os mesmos atributos dos spans de WithTracer, com exceção de db.query.text.
+//
+// Exemplo de uso:
+//
+//	exec := executor.New(db, executor.WithMeter(otel.Meter("users")))
+func WithMeter(meter metric.Meter) Option {
+	return func(e *Executor) {
+		var (
+			m    metrics
+			errs [2]error
+		)
+
+		m.duration, errs[0] = meter.Float64Histogram("db.client.operation.duration",
+			metric.WithDescription("Duration of database client operations."),
+			metric.WithUnit("s"),
+			metric.WithExplicitBucketBoundaries(0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10))
+		m.returnedRows, errs[1] = meter.Int64Histogram("db.client.response.returned_rows",
+			metric.WithDescription("Actual number of records returned by the database operation."),
+			metric.WithUnit("{row}"),
+			metric.WithExplicitBucketBoundaries(1, 2, 5, 10, 20, 50, 100, 500, 1000, 10000))
+
+		// Em caso de erro a API retorna instrumentos válidos que não registram nada, então o erro só é reportado
+		for _, err := range errs {
+			if err != nil {
+				otel.Handle(err)
+			}
+		}
+
+		e.metrics = &m
+	}
+}
+
+func (e *Executor) record(ctx context.Context, attrs []attribute.KeyValue, duration time.Duration, rows int64) {
+	if e.metrics == nil {
+		return
+	}
+
+	set := metric.WithAttributes(attrs...)
+
+	e.metrics.duration.Record(ctx, duration.Seconds(), set)
+	if rows >= 0 {
+		e.metrics.returnedRows.Record(ctx, rows, set)
+	}
+}
@@ -0,0 +1,67 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	query "github.com/MMortari/go-query-builder"
+)
+
+func TestMeter(t *testing.T) {
+	db, mock := newMock(t)
+
+	reader := sdkmetric.NewManualReader()
+	exec := New(db, WithMeter(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test")))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM "users"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total FROM "users"`)).
+		WillReturnError(errors.New("connection reset"))
+
+	var ids []int64
+	require.NoError(t, exec.Select(context.Background(), query.NewQueryBuilder().From("users").Select("id"), &ids))
+
+	_, err := exec.Count(context.Background(), query.NewQueryBuilder().From("users"))
+	require.Error(t, err)
+
+	var data metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &data))
+	require.Len(t, data.ScopeMetrics, 1)
+
+	metrics := make(map[string]metricdata.Aggregation)
+	for _, item := range data.ScopeMetrics[0].Metrics {
+		metrics[item.Name] = item.Data
+	}
+
+	duration := metrics["db.client.operation.duration"].(metricdata.Histogram[float64]).DataPoints
+	require.Len(t, duration, 2)
+	for _, item := range duration {
+		operation, _ := item.Attributes.Value("db.operation.name")
+		collection, _ := item.Attributes.Value("db.collection.name")
+		assert.Equal(t, "SELECT", operation.AsString())
+		assert.Equal(t, "users", collection.AsString())
+		assert.Equal(t, uint64(1), item.Count)
+	}
+
+	rows := metrics["db.client.response.returned_rows"].(metricdata.Histogram[int64]).DataPoints
+	require.Len(t, rows, 1)
+	assert.Equal(t, int64(2), rows[0].Sum)
+	assert.False(t, rows[0].Attributes.HasValue("error.type"))
+
+	var failed bool
+	for _, item := range duration {
+		if value, ok := item.Attributes.Value("error.type"); ok {
+			failed = true
+			assert.Equal(t, "*errors.errorString", value.AsString())
+		}
+	}
+	assert.True(t, failed)
+}
@@ -0,0 +1,168 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	query "github.com/MMortari/go-query-builder"
+)
+
+// Page é o resultado de uma consulta paginada.
+type Page[T any] struct {
+	Items      []T   `json:"items"`
+	Total      int64 `json:"total"`
+	Page       int   `json:"page"`
+	PageSize   int   `json:"page_size"`
+	TotalPages int   `json:"total_pages"`
+	HasNext    bool  `json:"has_next"`
+}
+
+type PaginateOption func(*paginateOptions)
+
+type paginateOptions struct {
+	concurrent bool
+	window     bool
+}
+
+// Concurrently executa a query da página e a de contagem ao mesmo tempo.
+//
+// Exige um Querier que aceite uso concorrente, como *sql.DB; não deve ser utilizado com *sql.Tx ou *sql.Conn.
+func Concurrently() PaginateOption {
+	return func(o *paginateOptions) {
+		o.concurrent = true
+	}
+}
+
+// WindowCount obtém a página e o total em uma única query, utilizando ToSelectWithTotalSql (`COUNT(*) OVER()`).
+//
+// Quando a página solicitada está além da última página, a query não retorna linhas e o total é obtido com
+// ToSelectTotalSql.
+func WindowCount() PaginateOption {
+	return func(o *paginateOptions) {
+		o.window = true
+	}
+}
+
+var ErrInvalidPageSize = errors.New("executor: page size must be greater than zero")
+
+// Paginate aplica PaginationPaged(page, pageSize) em qb e retorna a página de resultados junto ao total de registros.
+//
+// Por padrão a query da página (ToSelectSql) e a de contagem (ToSelectTotalSql) são executadas em sequência;
+// veja Concurrently e WindowCount para as alternativas. Páginas menores que 1 são tratadas como a primeira página.
+// O LIMIT / OFFSET da página é aplicado em uma cópia, então qb não é alterado.
+//
+// Exemplo de uso:
+//
+//	page, err := executor.Paginate[User](ctx, exec, qb, 2, 20)
+//	// page.Items, page.Total, page.TotalPages, page.HasNext
+func Paginate[T any](ctx context.Context, e *Executor, qb *query.QueryBuilder, page int, pageSize int, opts ...PaginateOption) (Page[T], error) {
+	options := paginateOptions{}
+	for _, item := range opts {
+		item(&options)
+	}
+
+	if pageSize <= 0 {
+		return Page[T]{}, ErrInvalidPageSize
+	}
+	if page < 1 {
+		page = 1
+	}
+
+	result := Page[T]{Items: make([]T, 0), Page: page, PageSize: pageSize}
+
+	// A paginação é aplicada em uma cópia para não alterar o builder de quem chamou
+	qb = qb.Clone().PaginationPaged(page, pageSize)
+
+	var err error
+	switch {
+	case options.window:
+		err = paginateWindow(ctx, e, qb, &result)
+	case options.concurrent:
+		err = e.paginateConcurrent(ctx, qb, &result.Items, &result.Total)
+	default:
+		err = e.Select(ctx, qb, &result.Items)
+		if err == nil {
+			result.Total, err = e.Count(ctx, qb)
+		}
+	}
+	if err != nil {
+		return Page[T]{}, err
+	}
+
+	result.TotalPages = int((result.Total + int64(pageSize) - 1) / int64(pageSize))
+	result.HasNext = page < result.TotalPages
+
+	return result, nil
+}
+
+func (e *Executor) paginateConcurrent(ctx context.Context, qb *query.QueryBuilder, items any, total *int64) error {
+	stmt, err := qb.BuildSelect()
+	if err != nil {
+		return err
+	}
+	stmtTotal, err := qb.BuildSelectTotal()
+	if err != nil {
+		return err
+	}
+
+	var (
+		wg                 sync.WaitGroup
+		errItems, errTotal error
+	)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		errTotal = e.queryRow(ctx, qb, stmtTotal, total)
+	}()
+
+	rows, errItems := e.query(ctx, qb, stmt)
+	if errItems == nil {
+		errItems = rows.close(ScanAll(rows, items))
+	}
+
+	wg.Wait()
+
+	return errors.Join(errItems, errTotal)
+}
+func paginateWindow[T any](ctx context.Context, e *Executor, qb *query.QueryBuilder, result *Page[T]) error {
+	stmt, err := qb.BuildSelectWithTotal()
+	if err != nil {
+		return err
+	}
+
+	rows, err := e.query(ctx, qb, stmt)
+	if err != nil {
+		return err
+	}
+
+	err = rows.close(ScanAll(&windowRows{Rows: rows, total: &result.Total}, &result.Items))
+	if err != nil {
+		return err
+	}
+
+	if len(result.Items) == 0 && result.Page > 1 {
+		result.Total, err = e.Count(ctx, qb)
+	}
+
+	return err
+}
+
+// windowRows esconde a coluna `total` adicionada por ToSelectWithTotalSql, lendo o seu valor em total.
+type windowRows struct {
+	Rows
+	total *int64
+}
+
+func (r *windowRows) Columns() ([]string, error) {
+	columns, err := r.Rows.Columns()
+	if err != nil || len(columns) == 0 {
+		return columns, err
+	}
+
+	return columns[:len(columns)-1], nil
+}
+func (r *windowRows) Scan(dest ...any) error {
+	return r.Rows.Scan(append(dest, r.total)...)
+}
@@ -0,0 +1,169 @@
+package executor
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	query "github.com/MMortari/go-query-builder"
+)
+
+type paginateUser struct {
+	ID   int64  `db:"id"`
+	Name string `db:"name"`
+}
+
+func TestPaginate(t *testing.T) {
+	ctx := context.Background()
+	qbUsers := func() *query.QueryBuilder {
+		return query.NewQueryBuilder().From("users").Select("id", "name").WhereAnd(query.Where{Column: "active", Type: "=", Val: true}).OrderBy(query.OrderBy{Column: "id"})
+	}
+
+	t.Run("Test Paginate", func(t *testing.T) {
+		db, mock := newMock(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name FROM "users" WHERE (active = $1) ORDER BY id LIMIT 2 OFFSET 2`)).
+			WithArgs(true).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(3, "Mark").AddRow(4, "James"))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total FROM "users" WHERE (active = $1)`)).
+			WithArgs(true).
+			WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(5))
+
+		page, err := Paginate[paginateUser](ctx, New(db), qbUsers(), 2, 2)
+		require.NoError(t, err)
+
+		assert.Equal(t, Page[paginateUser]{
+			Items:      []paginateUser{{ID: 3, Name: "Mark"}, {ID: 4, Name: "James"}},
+			Total:      5,
+			Page:       2,
+			PageSize:   2,
+			TotalPages: 3,
+			HasNext:    true,
+		}, page)
+	})
+
+	t.Run("Test Paginate Keeps Builder", func(t *testing.T) {
+		db, mock := newMock(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name FROM "users" WHERE (active = $1) ORDER BY id LIMIT 2 OFFSET 2`)).
+			WithArgs(true).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total FROM "users" WHERE (active = $1)`)).
+			WithArgs(true).
+			WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(0))
+
+		qb := qbUsers()
+		_, err := Paginate[paginateUser](ctx, New(db), qb, 2, 2)
+		require.NoError(t, err)
+
+		sql, _ := qb.ToSelectSql()
+		assert.Equal(t, `SELECT id, name FROM "users" WHERE (active = $1) ORDER BY id`, sql)
+	})
+
+	t.Run("Test Paginate Concurrently", func(t *testing.T) {
+		db, mock := newMock(t)
+		mock.MatchExpectationsInOrder(false)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name FROM "users" WHERE (active = $1) ORDER BY id LIMIT 2 OFFSET 4`)).
+			WithArgs(true).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(5, "Mark"))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total FROM "users" WHERE (active = $1)`)).
+			WithArgs(true).
+			WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(5))
+
+		page, err := Paginate[paginateUser](ctx, New(db), qbUsers(), 3, 2, Concurrently())
+		require.NoError(t, err)
+
+		assert.Equal(t, []paginateUser{{ID: 5, Name: "Mark"}}, page.Items)
+		assert.Equal(t, int64(5), page.Total)
+		assert.Equal(t, 3, page.TotalPages)
+		assert.False(t, page.HasNext)
+	})
+
+	t.Run("Test Paginate Window Count", func(t *testing.T) {
+		db, mock := newMock(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, COUNT(*) OVER() AS total FROM "users" WHERE (active = $1) ORDER BY id LIMIT 2 OFFSET 0`)).
+			WithArgs(true).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "total"}).AddRow(1, "Mark", 3).AddRow(2, "James", 3))
+
+		page, err := Paginate[paginateUser](ctx, New(db), qbUsers(), 0, 2, WindowCount())
+		require.NoError(t, err)
+
+		assert.Equal(t, []paginateUser{{ID: 1, Name: "Mark"}, {ID: 2, Name: "James"}}, page.Items)
+		assert.Equal(t, int64(3), page.Total)
+		assert.Equal(t, 1, page.Page)
+		assert.Equal(t, 2, page.TotalPages)
+		assert.True(t, page.HasNext)
+	})
+
+	t.Run("Test Paginate Window Count Out Of Range", func(t *testing.T) {
+		db, mock := newMock(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, COUNT(*) OVER() AS total FROM "users" WHERE (active = $1) ORDER BY id LIMIT 2 OFFSET 18`)).
+			WithArgs(true).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "total"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total FROM "users" WHERE (active = $1)`)).
+			WithArgs(true).
+			WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(3))
+
+		page, err := Paginate[paginateUser](ctx, New(db), qbUsers(), 10, 2, WindowCount())
+		require.NoError(t, err)
+
+		assert.Empty(t, page.Items)
+		assert.Equal(t, int64(3), page.Total)
+		assert.False(t, page.HasNext)
+	})
+
+	t.Run("Test Paginate Concurrently Hook", func(t *testing.T) {
+		db, mock := newMock(t)
+		mock.MatchExpectationsInOrder(false)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, similarity(name, $1) AS score FROM "users" WHERE (email = $2) LIMIT 2 OFFSET 0`)).
+			WithArgs("mark", "john@doe.com").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Mark"))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total FROM "users" WHERE (email = $1)`)).
+			WithArgs("john@doe.com").
+			WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(1))
+
+		var (
+			mu     sync.Mutex
+			events = map[string]query.Event{}
+		)
+		qb := query.NewQueryBuilder(
+			query.SetHook(query.HookFunc(func(ctx context.Context, event query.Event) {
+				mu.Lock()
+				defer mu.Unlock()
+				if event.Kind == query.EventExecute {
+					events[event.SQL] = event
+				}
+			})),
+			query.Redact(query.RedactionPolicy{Allow: []string{"email"}}),
+		).
+			From("users").
+			Select("id", "name").
+			SelectExpr(query.Raw("similarity(name, ?) AS score", "mark")).
+			WhereAnd(query.Where{Column: "email", Type: "=", Val: "john@doe.com"})
+
+		_, err := Paginate[paginateUser](ctx, New(db), qb, 1, 2, Concurrently())
+		require.NoError(t, err)
+
+		require.Len(t, events, 2)
+		// Cada execução é redigida com as colunas da sua própria query
+		assert.Equal(t, []any{query.RedactedValue, "john@doe.com"}, events[`SELECT id, name, similarity(name, $1) AS score FROM "users" WHERE (email = $2) LIMIT 2 OFFSET 0`].Args)
+		assert.Equal(t, []any{"john@doe.com"}, events[`SELECT COUNT(*) AS total FROM "users" WHERE (email = $1)`].Args)
+	})
+
+	t.Run("Test Paginate Invalid Page Size", func(t *testing.T) {
+		db, _ := newMock(t)
+
+		_, err := Paginate[paginateUser](ctx, New(db), qbUsers(), 1, 0)
+
+		assert.ErrorIs(t, err, ErrInvalidPageSize)
+	})
+}
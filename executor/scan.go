@@ -0,0 +1,293 @@
+package executor
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rows é o subconjunto de *sql.Rows utilizado pelo mapeamento de structs.
+//
+// Outros drivers podem ser adaptados para esta interface para reaproveitar ScanAll e ScanOne.
+type Rows interface {
+	Columns() ([]string, error)
+	Next() bool
+	Scan(dest ...any) error
+	Err() error
+}
+
+var ErrUnmappedColumn = errors.New("executor: unmapped column")
+
+var (
+	scannerType = reflect.TypeFor[sql.Scanner]()
+	timeType    = reflect.TypeFor[time.Time]()
+
+	fieldsCache sync.Map // map[reflect.Type]map[string][]int
+)
+
+// ScanAll lê todas as linhas de rows no slice apontado por dest.
+//
+// Os elementos do slice podem ser structs (ou ponteiros para structs), mapeadas pela tag `db`, ou tipos simples
+// quando a query retorna uma única coluna.
+//
+// Colunas com prefixo de alias, como `p.phone`, são lidas no campo com tag `db:"p"` do tipo struct, permitindo
+// separar as colunas de tabelas unidas via Join.As. Como o banco não retorna o alias junto ao nome da coluna,
+// a coluna deve ser selecionada com o nome completo, ex: `p.phone AS "p.phone"`. Quando o campo é um ponteiro e
+// todas as suas colunas são NULL, como em um LEFT JOIN sem correspondência, o campo permanece nil.
+//
+// Exemplo de uso:
+//
+//	type Phone struct {
+//	    Phone string `db:"phone"`
+//	}
+//	type User struct {
+//	    ID    int64          `db:"id"`
+//	    Name  sql.NullString `db:"name"`
+//	    Phone *Phone         `db:"p"`
+//	}
+//
+//	var users []User
+//	err := executor.ScanAll(rows, &users)
+func ScanAll(rows Rows, dest any) error {
+	slice, err := sliceOf(dest)
+	if err != nil {
+		return err
+	}
+
+	elemType := slice.Type().Elem()
+	baseType := elemType
+	if baseType.Kind() == reflect.Pointer {
+		baseType = baseType.Elem()
+	}
+
+	targets, err := scanTargets(rows, baseType)
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		elem := reflect.New(baseType)
+		values, assign := targets(elem.Elem())
+		if err := rows.Scan(values...); err != nil {
+			return err
+		}
+		assign()
+
+		if elemType.Kind() == reflect.Pointer {
+			slice.Set(reflect.Append(slice, elem))
+		} else {
+			slice.Set(reflect.Append(slice, elem.Elem()))
+		}
+	}
+
+	return rows.Err()
+}
+
+// ScanOne lê a primeira linha de rows em dest, que deve ser um ponteiro.
+//
+// Retorna sql.ErrNoRows quando não há nenhuma linha.
+func ScanOne(rows Rows, dest any) error {
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return fmt.Errorf("executor: dest must be a non-nil pointer, got %T", dest)
+	}
+
+	targets, err := scanTargets(rows, value.Elem().Type())
+	if err != nil {
+		return err
+	}
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+
+	values, assign := targets(value.Elem())
+	if err := rows.Scan(values...); err != nil {
+		return err
+	}
+	assign()
+
+	return rows.Err()
+}
+
+// scanTargets resolve as colunas de rows nos campos de destType e retorna a função que gera os ponteiros de
+// destino para cada linha, junto à função que copia para a struct os valores lidos em destinos intermediários.
+//
+// Colunas de structs aninhadas por ponteiro (ex: `Phone *Phone`) são lidas em destinos que aceitam NULL, e o ponteiro
+// só é alocado quando alguma de suas colunas não é NULL. Assim um LEFT JOIN sem correspondência mantém o campo nil.
+func scanTargets(rows Rows, destType reflect.Type) (func(reflect.Value) ([]any, func()), error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	if !isStruct(destType) {
+		if len(columns) != 1 {
+			return nil, fmt.Errorf("executor: cannot scan %d columns into %s", len(columns), destType)
+		}
+
+		return func(v reflect.Value) ([]any, func()) {
+			return []any{v.Addr().Interface()}, func() {}
+		}, nil
+	}
+
+	fields := structFields(destType)
+
+	type target struct {
+		index []int
+		// nullable é o tipo do campo quando o caminho até ele passa por um ponteiro para struct
+		nullable reflect.Type
+	}
+
+	targets := make([]target, 0, len(columns))
+	for _, column := range columns {
+		index, ok := fields[column]
+		if !ok {
+			return nil, fmt.Errorf("%w: column %q has no matching field in %s", ErrUnmappedColumn, column, destType)
+		}
+
+		item := target{index: index}
+		if fieldType, ok := nestedPointerField(destType, index); ok {
+			item.nullable = fieldType
+		}
+		targets = append(targets, item)
+	}
+
+	return func(v reflect.Value) ([]any, func()) {
+		dest := make([]any, 0, len(targets))
+		holders := make([]reflect.Value, len(targets))
+		for i, item := range targets {
+			if item.nullable == nil {
+				dest = append(dest, fieldByIndex(v, item.index).Addr().Interface())
+				continue
+			}
+
+			holders[i] = reflect.New(reflect.PointerTo(item.nullable))
+			dest = append(dest, holders[i].Interface())
+		}
+
+		return dest, func() {
+			for i, holder := range holders {
+				if holder.IsValid() && !holder.Elem().IsNil() {
+					fieldByIndex(v, targets[i].index).Set(holder.Elem().Elem())
+				}
+			}
+		}
+	}, nil
+}
+
+// nestedPointerField retorna o tipo do campo em index quando o caminho até ele passa por um ponteiro para struct.
+func nestedPointerField(t reflect.Type, index []int) (reflect.Type, bool) {
+	var pointer bool
+	for i, fieldIndex := range index {
+		if i > 0 && t.Kind() == reflect.Pointer {
+			pointer = true
+			t = t.Elem()
+		}
+		t = t.Field(fieldIndex).Type
+	}
+
+	return t, pointer
+}
+
+// structFields mapeia os nomes de coluna aceitos por t para o caminho do campo correspondente.
+func structFields(t reflect.Type) map[string][]int {
+	if fields, ok := fieldsCache.Load(t); ok {
+		return fields.(map[string][]int)
+	}
+
+	fields := make(map[string][]int)
+	collectFields(t, "", nil, fields)
+
+	fieldsCache.Store(t, fields)
+
+	return fields
+}
+func collectFields(t reflect.Type, prefix string, index []int, fields map[string][]int) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		name, _ := parseTag(field)
+		if name == "-" {
+			continue
+		}
+
+		fieldIndex := append(append(make([]int, 0, len(index)+1), index...), i)
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+
+		if isStruct(fieldType) {
+			// Structs embutidas sem tag têm seus campos promovidos, como no Go
+			if field.Anonymous && name == "" {
+				collectFields(fieldType, prefix, fieldIndex, fields)
+				continue
+			}
+			if name == "" {
+				name = strings.ToLower(field.Name)
+			}
+
+			collectFields(fieldType, prefix+name+".", fieldIndex, fields)
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		if _, ok := fields[prefix+name]; !ok {
+			fields[prefix+name] = fieldIndex
+		}
+	}
+}
+
+// parseTag retorna o nome da coluna e as opções da tag `db` do campo.
+func parseTag(field reflect.StructField) (name string, options []string) {
+	tag, ok := field.Tag.Lookup("db")
+	if !ok {
+		return "", nil
+	}
+
+	parts := strings.Split(tag, ",")
+
+	return parts[0], parts[1:]
+}
+
+// isStruct indica se t deve ser percorrido campo a campo, ou seja, é uma struct que não é lida diretamente pelo driver.
+func isStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || t == timeType {
+		return false
+	}
+
+	return !reflect.PointerTo(t).Implements(scannerType)
+}
+
+// fieldByIndex é equivalente a reflect.Value.FieldByIndex, alocando os ponteiros nulos encontrados no caminho.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, fieldIndex := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(fieldIndex)
+	}
+
+	return v
+}
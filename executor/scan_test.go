@@ -0,0 +1,169 @@
+package executor
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	query "github.com/MMortari/go-query-builder"
+)
+
+type scanPhone struct {
+	Phone string `db:"phone"`
+	Kind  *string
+}
+type scanBase struct {
+	ID        int64     `db:"id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+type scanUser struct {
+	scanBase
+	Name    sql.NullString `db:"name"`
+	Age     *int           `db:"age"`
+	Ignored string         `db:"-"`
+	Phone   *scanPhone     `db:"p"`
+	Address struct {
+		City string `db:"city"`
+	} `db:"a"`
+}
+
+func TestScan(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("Test Select Struct", func(t *testing.T) {
+		db, mock := newMock(t)
+
+		qb := query.NewQueryBuilder().
+			From("users", "u").
+			Select("u.id", "u.created_at", "u.name", "u.age", `p.phone AS "p.phone"`, `p.kind AS "p.kind"`, `a.city AS "a.city"`).
+			Join(query.Join{Table: "phones", As: "p", On: "p.user_id = u.id", Type: query.LeftJoin}).
+			Join(query.Join{Table: "address", As: "a", On: "a.user_id = u.id"})
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT u.id, u.created_at, u.name, u.age, p.phone AS "p.phone", p.kind AS "p.kind", a.city AS "a.city" FROM "users" AS "u"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "name", "age", "p.phone", "p.kind", "a.city"}).
+				AddRow(1, createdAt, "Mark", 18, "5511999999999", "mobile", "São Paulo").
+				AddRow(2, createdAt, nil, nil, "5511888888888", nil, "Recife"))
+
+		var users []scanUser
+		err := New(db).Select(ctx, qb, &users)
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+
+		assert.Equal(t, int64(1), users[0].ID)
+		assert.Equal(t, createdAt, users[0].CreatedAt)
+		assert.Equal(t, sql.NullString{String: "Mark", Valid: true}, users[0].Name)
+		require.NotNil(t, users[0].Age)
+		assert.Equal(t, 18, *users[0].Age)
+		require.NotNil(t, users[0].Phone)
+		assert.Equal(t, "5511999999999", users[0].Phone.Phone)
+		require.NotNil(t, users[0].Phone.Kind)
+		assert.Equal(t, "mobile", *users[0].Phone.Kind)
+		assert.Equal(t, "São Paulo", users[0].Address.City)
+
+		assert.Equal(t, int64(2), users[1].ID)
+		assert.False(t, users[1].Name.Valid)
+		assert.Nil(t, users[1].Age)
+		assert.Nil(t, users[1].Phone.Kind)
+		assert.Equal(t, "Recife", users[1].Address.City)
+	})
+
+	t.Run("Test Select Left Join Null", func(t *testing.T) {
+		db, mock := newMock(t)
+
+		qb := query.NewQueryBuilder().
+			From("users", "u").
+			Select("u.id", `p.phone AS "p.phone"`, `p.kind AS "p.kind"`).
+			Join(query.Join{Table: "phones", As: "p", On: "p.user_id = u.id", Type: query.LeftJoin})
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT u.id, p.phone AS "p.phone", p.kind AS "p.kind" FROM "users" AS "u"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "p.phone", "p.kind"}).
+				AddRow(1, nil, nil).
+				AddRow(2, "5511888888888", nil))
+
+		var users []scanUser
+		err := New(db).Select(ctx, qb, &users)
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+
+		assert.Nil(t, users[0].Phone)
+
+		require.NotNil(t, users[1].Phone)
+		assert.Equal(t, "5511888888888", users[1].Phone.Phone)
+		assert.Nil(t, users[1].Phone.Kind)
+	})
+
+	t.Run("Test Select Struct Pointer", func(t *testing.T) {
+		db, mock := newMock(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name FROM "users"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Mark"))
+
+		var users []*scanUser
+		err := New(db).Select(ctx, query.NewQueryBuilder().From("users").Select("id", "name"), &users)
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+
+		assert.Equal(t, int64(1), users[0].ID)
+		assert.Equal(t, "Mark", users[0].Name.String)
+		assert.Nil(t, users[0].Phone)
+	})
+
+	t.Run("Test Select Unmapped Column", func(t *testing.T) {
+		db, mock := newMock(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, email FROM "users"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(1, "mark@email.com"))
+
+		var users []scanUser
+		err := New(db).Select(ctx, query.NewQueryBuilder().From("users").Select("id", "email"), &users)
+
+		assert.ErrorIs(t, err, ErrUnmappedColumn)
+		assert.ErrorContains(t, err, `"email"`)
+	})
+
+	t.Run("Test Select Scalar Multiple Columns", func(t *testing.T) {
+		db, mock := newMock(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name FROM "users"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Mark"))
+
+		var ids []int64
+		err := New(db).Select(ctx, query.NewQueryBuilder().From("users").Select("id", "name"), &ids)
+
+		assert.ErrorContains(t, err, "cannot scan 2 columns")
+	})
+
+	t.Run("Test Get Struct", func(t *testing.T) {
+		db, mock := newMock(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name FROM "users" WHERE (id = $1)`)).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Mark"))
+
+		var user scanUser
+		err := New(db).Get(ctx, query.NewQueryBuilder().From("users").Select("id", "name").WhereAnd(query.Where{Column: "id", Type: "=", Val: 1}), &user)
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(1), user.ID)
+		assert.Equal(t, "Mark", user.Name.String)
+	})
+
+	t.Run("Test Get Struct No Rows", func(t *testing.T) {
+		db, mock := newMock(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM "users"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		var user scanUser
+		err := New(db).Get(ctx, query.NewQueryBuilder().From("users").Select("id"), &user)
+
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
@@ -0,0 +1,182 @@
+package executor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+	"go.opentelemetry.io/otel/trace"
+
+	query "github.com/MMortari/go-query-builder"
+)
+
+type Option func(*Executor)
+
+// WithTracer faz o Executor criar um span para cada query executada, filho do span presente no context.
+//
+// O span é do tipo client, nomeado pela operação e pela tabela (ex: `SELECT users`) e segue as convenções semânticas
+// de banco de dados do OpenTelemetry: db.system.name, db.operation.name, db.collection.name, db.query.text e
+// db.response.returned_rows, além de db.query.summary e db.query.fingerprint (veja query.Summarize e
+// query.Fingerprint). UPDATE e DELETE registram o número de registros alterados em db.response.affected_rows.
+// Erros são registrados no span junto ao status de erro; sql.ErrNoRows não é considerado um erro.
+//
+// Diferente de SetOtelSpan, o span é iniciado e encerrado pelo Executor.
+//
+// Exemplo de uso:
+//
+//	exec := executor.New(db, executor.WithTracer(otel.Tracer("users")), executor.WithServer("db.internal", 5432))
+func WithTracer(tracer trace.Tracer) Option {
+	return func(e *Executor) {
+		e.tracer = tracer
+	}
+}
+
+// WithServer informa o endereço e a porta do banco, registrados em server.address e server.port nos spans criados
+// com WithTracer. Uma porta zero não é registrada.
+func WithServer(address string, port int) Option {
+	return func(e *Executor) {
+		e.serverAddress = address
+		e.serverPort = port
+	}
+}
+
+// Observation acompanha uma query em execução, encerrando o span, registrando as métricas e notificando o Hook do
+// builder ao final.
+type Observation struct {
+	e     *Executor
+	ctx   context.Context
+	qb    *query.QueryBuilder
+	stmt  query.Statement
+	span  trace.Span
+	start time.Time
+	attrs []attribute.KeyValue
+}
+
+// Observe inicia a observação de stmt com as opções do Executor (WithTracer, WithMeter e WithServer), retornando o
+// context com o span da query. A observação deve ser encerrada com End após a execução.
+//
+// É utilizado pelos métodos do Executor e permite que outros drivers, como o pacote pgxexec, tenham os mesmos spans,
+// métricas e eventos de Hook. Um Executor criado sem Querier pode ser utilizado somente para observar.
+//
+// Exemplo de uso:
+//
+//	ctx, o := observer.Observe(ctx, qb, stmt)
+//	tag, err := conn.Exec(ctx, stmt.SQL, stmt.Args...)
+//	o.End(-1, err)
+func (e *Executor) Observe(ctx context.Context, qb *query.QueryBuilder, stmt query.Statement) (context.Context, *Observation) {
+	o := &Observation{e: e, ctx: ctx, qb: qb, stmt: stmt, start: time.Now()}
+	if e.tracer == nil && e.metrics == nil {
+		return ctx, o
+	}
+
+	name := stmt.Operation
+	o.attrs = []attribute.KeyValue{semconv.DBSystemNamePostgreSQL, semconv.DBOperationName(stmt.Operation)}
+
+	if table := qb.Table(); table != "" {
+		name += " " + table
+		o.attrs = append(o.attrs, semconv.DBCollectionName(table))
+	}
+	if e.serverAddress != "" {
+		o.attrs = append(o.attrs, semconv.ServerAddress(e.serverAddress))
+	}
+	if e.serverPort != 0 {
+		o.attrs = append(o.attrs, semconv.ServerPort(e.serverPort))
+	}
+
+	if e.tracer != nil {
+		attrs := slices.Clip(o.attrs)
+		if stmt.Text != "" {
+			attrs = append(attrs, semconv.DBQueryText(stmt.Text))
+		}
+		if summary, err := query.Summarize(stmt.SQL); err == nil {
+			attrs = append(attrs, semconv.DBQuerySummary(summary))
+		}
+		if fingerprint, err := query.Fingerprint(stmt.SQL); err == nil {
+			attrs = append(attrs, attribute.String("db.query.fingerprint", fingerprint))
+		}
+		ctx, o.span = e.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	}
+
+	return ctx, o
+}
+
+// End registra o número de linhas retornadas, quando conhecido (rows >= 0), e o erro, e encerra o span.
+// sql.ErrNoRows não é considerado um erro.
+func (o *Observation) End(rows int64, err error) {
+	if errors.Is(err, sql.ErrNoRows) {
+		err = nil
+	}
+
+	attrs := o.attrs
+	if err != nil {
+		attrs = append(slices.Clip(attrs), semconv.ErrorTypeKey.String(reflect.TypeOf(err).String()))
+	}
+
+	duration := time.Since(o.start)
+
+	o.e.record(o.ctx, attrs, duration, rows)
+	o.qb.Emit(o.ctx, query.Event{
+		Kind:      query.EventExecute,
+		Operation: o.stmt.Operation,
+		SQL:       o.stmt.Text,
+		Args:      o.stmt.Args,
+		Columns:   o.stmt.Columns,
+		Duration:  duration,
+		Err:       err,
+	})
+
+	if o.span == nil {
+		return
+	}
+
+	if rows >= 0 {
+		o.span.SetAttributes(semconv.DBResponseReturnedRows(int(rows)))
+	}
+	if err != nil {
+		o.span.RecordError(err)
+		o.span.SetStatus(codes.Error, err.Error())
+		o.span.SetAttributes(attrs[len(attrs)-1])
+	}
+
+	o.span.End()
+}
+
+// EndExec encerra a observação de um comando sem linhas de retorno (UPDATE / DELETE), registrando o número de
+// registros alterados em db.response.affected_rows quando conhecido (affected >= 0).
+func (o *Observation) EndExec(affected int64, err error) {
+	if o.span != nil && affected >= 0 {
+		o.span.SetAttributes(attribute.Int64("db.response.affected_rows", affected))
+	}
+
+	o.End(-1, err)
+}
+
+// tracedRows conta as linhas lidas para registrar db.response.returned_rows ao encerrar a observação.
+type tracedRows struct {
+	*sql.Rows
+	observation *Observation
+	count       int64
+}
+
+func (r *tracedRows) Next() bool {
+	if !r.Rows.Next() {
+		return false
+	}
+
+	r.count++
+	return true
+}
+
+// close fecha as linhas e encerra a observação com o erro do processamento, retornando o mesmo erro.
+func (r *tracedRows) close(err error) error {
+	r.Rows.Close()
+	r.observation.End(r.count, err)
+
+	return err
+}
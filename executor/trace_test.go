@@ -0,0 +1,186 @@
+package executor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	query "github.com/MMortari/go-query-builder"
+)
+
+func newTracedMock(t *testing.T) (*Executor, sqlmock.Sqlmock, *tracetest.SpanRecorder, trace.Tracer) {
+	db, mock := newMock(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	return New(db, WithTracer(tracer), WithServer("db.internal", 5432)), mock, recorder, tracer
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, item := range span.Attributes() {
+		attrs[item.Key] = item.Value
+	}
+
+	return attrs
+}
+
+func TestTracer(t *testing.T) {
+	t.Run("Test Select Span", func(t *testing.T) {
+		exec, mock, recorder, tracer := newTracedMock(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM "users" AS "u" WHERE (age > $1)`)).
+			WithArgs(18).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2).AddRow(3))
+
+		ctx, parent := tracer.Start(context.Background(), "handler")
+
+		var ids []int64
+		err := exec.Select(ctx, query.NewQueryBuilder().From("users", "u").Select("id").WhereAnd(query.Where{Column: "age", Type: ">", Val: 18}), &ids)
+		require.NoError(t, err)
+		parent.End()
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+
+		span := spans[0]
+		assert.Equal(t, "SELECT users", span.Name())
+		assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+		assert.Equal(t, codes.Unset, span.Status().Code)
+
+		attrs := spanAttributes(span)
+		assert.Equal(t, "postgresql", attrs["db.system.name"].AsString())
+		assert.Equal(t, "SELECT", attrs["db.operation.name"].AsString())
+		assert.Equal(t, "users", attrs["db.collection.name"].AsString())
+		assert.Equal(t, `SELECT id FROM "users" AS "u" WHERE (age > $1)`, attrs["db.query.text"].AsString())
+		assert.Equal(t, "db.internal", attrs["server.address"].AsString())
+		assert.Equal(t, int64(5432), attrs["server.port"].AsInt64())
+		assert.Equal(t, int64(3), attrs["db.response.returned_rows"].AsInt64())
+		assert.Equal(t, "SELECT users", attrs["db.query.summary"].AsString())
+
+		fingerprint, err := query.Fingerprint(`SELECT id FROM "users" AS "u" WHERE (age > $1)`)
+		require.NoError(t, err)
+		assert.Equal(t, fingerprint, attrs["db.query.fingerprint"].AsString())
+	})
+
+	t.Run("Test Exec Error", func(t *testing.T) {
+		exec, mock, recorder, _ := newTracedMock(t)
+
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET name = $1 WHERE (id = $2)`)).
+			WithArgs("Mark", 7).
+			WillReturnError(errors.New("connection reset"))
+
+		_, err := exec.Exec(context.Background(), query.NewQueryBuilder().From("users").Values(query.Value{Column: "name", Val: "Mark"}).WhereAnd(query.Where{Column: "id", Type: "=", Val: 7}))
+		require.Error(t, err)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+
+		span := spans[0]
+		assert.Equal(t, "UPDATE users", span.Name())
+		assert.Equal(t, codes.Error, span.Status().Code)
+		assert.Equal(t, "connection reset", span.Status().Description)
+		require.Len(t, span.Events(), 1)
+		assert.Equal(t, "exception", span.Events()[0].Name)
+
+		attrs := spanAttributes(span)
+		assert.Equal(t, "UPDATE", attrs["db.operation.name"].AsString())
+		assert.Equal(t, "*errors.errorString", attrs["error.type"].AsString())
+		assert.NotContains(t, attrs, attribute.Key("db.response.returned_rows"))
+		assert.NotContains(t, attrs, attribute.Key("db.response.affected_rows"))
+	})
+
+	t.Run("Test Exec Affected Rows", func(t *testing.T) {
+		exec, mock, recorder, _ := newTracedMock(t)
+
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET active = $1 WHERE (age > $2)`)).
+			WithArgs(false, 90).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "users" WHERE (id = $1)`)).
+			WithArgs(7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		_, err := exec.Exec(context.Background(), query.NewQueryBuilder().From("users").Values(query.Value{Column: "active", Val: false}).WhereAnd(query.Where{Column: "age", Type: ">", Val: 90}))
+		require.NoError(t, err)
+		_, err = exec.Delete(context.Background(), query.NewQueryBuilder().From("users").WhereAnd(query.Where{Column: "id", Type: "=", Val: 7}))
+		require.NoError(t, err)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+
+		assert.Equal(t, "UPDATE users", spans[0].Name())
+		assert.Equal(t, int64(3), spanAttributes(spans[0])["db.response.affected_rows"].AsInt64())
+		assert.NotContains(t, spanAttributes(spans[0]), attribute.Key("db.response.returned_rows"))
+
+		assert.Equal(t, "DELETE users", spans[1].Name())
+		assert.Equal(t, int64(1), spanAttributes(spans[1])["db.response.affected_rows"].AsInt64())
+	})
+
+	t.Run("Test Get No Rows", func(t *testing.T) {
+		exec, mock, recorder, _ := newTracedMock(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total FROM "users"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"total"}))
+
+		var total int64
+		err := exec.Get(context.Background(), query.NewQueryBuilder().From("users").Select("COUNT(*) AS total"), &total)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, codes.Unset, spans[0].Status().Code)
+		assert.Equal(t, int64(0), spanAttributes(spans[0])["db.response.returned_rows"].AsInt64())
+	})
+
+	t.Run("Test Without Tracer", func(t *testing.T) {
+		db, mock := newMock(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+		rows, err := New(db).Query(context.Background(), query.NewQueryBuilder().From("users"))
+		require.NoError(t, err)
+		rows.Close()
+	})
+}
+
+func TestHook(t *testing.T) {
+	db, mock := newMock(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM "users" WHERE (email = $1)`)).
+		WithArgs("john@doe.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	var events []query.Event
+	qb := query.NewQueryBuilder(
+		query.SetHook(query.HookFunc(func(ctx context.Context, event query.Event) {
+			events = append(events, event)
+		})),
+		query.Redact(query.RedactionPolicy{}),
+	).From("users").Select("id").WhereAnd(query.Where{Column: "email", Type: "=", Val: "john@doe.com"})
+
+	var ids []int64
+	require.NoError(t, New(db).Select(context.Background(), qb, &ids))
+
+	require.Len(t, events, 2)
+	assert.Equal(t, query.EventBuild, events[0].Kind)
+	assert.Equal(t, query.EventExecute, events[1].Kind)
+	assert.Equal(t, "SELECT", events[1].Operation)
+	assert.Equal(t, "users", events[1].Table)
+	assert.Equal(t, `SELECT id FROM "users" WHERE (email = $1)`, events[1].SQL)
+	assert.Equal(t, []any{query.RedactedValue}, events[1].Args)
+	assert.NoError(t, events[1].Err)
+}
@@ -0,0 +1,171 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrExprArgs é registrado em Err() quando o número de `?` de uma Expr é diferente do número de parâmetros.
+var ErrExprArgs = errors.New("query: expression placeholders do not match args")
+
+// Expr é um fragmento de SQL com parâmetros, criado com Raw.
+//
+// Cada `?` em SQL é substituído, na ordem, por um placeholder numerado (`$n`) com o valor correspondente de Args.
+// Para escrever o operador `?` do Postgres (ex: jsonb), utilize `??`. Interrogações dentro de strings e
+// identificadores entre aspas não são substituídas. Com ParseWhere(false), os valores das Expr de Where são inseridos
+// no SQL como literais do Postgres; nas demais posições continuam como parâmetros.
+//
+// Expr é aceito em:
+//
+//   - Value.Val: `Value{Column: "count", Val: query.Raw("count + ?", 1)}` gera `count = count + $1`.
+//   - Where.Val e Where.ColumnExpr: `Where{ColumnExpr: query.Raw("lower(name)"), Type: "=", Val: "mark"}`.
+//   - OrderBy.ColumnExpr, SelectExpr e GroupByExpr.
+type Expr struct {
+	SQL  string
+	Args []any
+}
+
+// Raw cria uma Expr com o SQL e os parâmetros informados.
+//
+// Exemplo de uso:
+//
+//	qb := query.NewQueryBuilder().
+//	    From("users").
+//	    Values(
+//	        query.Value{Column: "updated_at", Val: query.Raw("NOW()")},
+//	        query.Value{Column: "logins", Val: query.Raw("logins + ?", 1)},
+//	    )
+//	// SQL: UPDATE "users" SET updated_at = NOW(), logins = logins + $1
+func Raw(sql string, args ...any) Expr {
+	return Expr{SQL: sql, Args: args}
+}
+
+// SelectExpr adiciona expressões com parâmetros às colunas selecionadas.
+//
+// Exemplo de uso:
+//
+//	qb.SelectExpr(query.Raw("similarity(name, ?) AS score", term))
+func (q *QueryBuilder) SelectExpr(exprs ...Expr) *QueryBuilder {
+	q = q.builder()
+	q.selects = append(q.selects, exprs...)
+	q.setSpanAttribute("db.operation.name", "SELECT")
+	return q
+}
+
+// GroupByExpr adiciona expressões com parâmetros ao agrupamento.
+//
+// Exemplo de uso:
+//
+//	qb.GroupByExpr(query.Raw("date_trunc(?, created_at)", "month"))
+func (q *QueryBuilder) GroupByExpr(exprs ...Expr) *QueryBuilder {
+	q = q.builder()
+	q.groupBy = append(q.groupBy, exprs...)
+	return q
+}
+
+// columnExprs converte nomes de colunas em Exprs sem parâmetros, preservando as interrogações.
+func columnExprs(names []string) []Expr {
+	exprs := make([]Expr, 0, len(names))
+	for _, item := range names {
+		exprs = append(exprs, Expr{SQL: escapePlaceholders(item)})
+	}
+
+	return exprs
+}
+
+// escapePlaceholders duplica os `?` de sql, exceto os de strings, identificadores entre aspas e comentários, que
+// renderExpr copia sem alteração.
+func escapePlaceholders(sql string) string {
+	var result strings.Builder
+	for i := 0; i < len(sql); {
+		if end := skipLiteral(sql, i); end > i {
+			result.WriteString(sql[i:end])
+			i = end
+			continue
+		}
+
+		if sql[i] == '?' {
+			result.WriteByte('?')
+		}
+		result.WriteByte(sql[i])
+		i++
+	}
+
+	return result.String()
+}
+
+// renderExpr substitui os `?` da expressão pelos placeholders a partir de itemNum. Com inline, utilizado no WHERE
+// com ParseWhere(false), os valores são inseridos no SQL como literais do Postgres. column identifica os parâmetros
+// no span e no Hook.
+//
+// Retorna ErrExprArgs quando o número de `?` é diferente do número de parâmetros.
+func (q *QueryBuilder) renderExpr(expr Expr, column string, inline bool, itemNum *int, queryData *[]interface{}, columns *[]string) (string, error) {
+	var (
+		result strings.Builder
+		arg    int
+	)
+
+	sql := expr.SQL
+	for i := 0; i < len(sql); {
+		if end := skipLiteral(sql, i); end > i {
+			result.WriteString(sql[i:end])
+			i = end
+			continue
+		}
+
+		if sql[i] != '?' {
+			result.WriteByte(sql[i])
+			i++
+			continue
+		}
+
+		if i+1 < len(sql) && sql[i+1] == '?' {
+			result.WriteByte('?')
+			i += 2
+			continue
+		}
+		i++
+
+		if arg >= len(expr.Args) {
+			// O placeholder é mantido para que a query continue válida e o erro fique disponível em Err()
+			arg++
+			result.WriteString("NULL")
+			continue
+		}
+
+		value := expr.Args[arg]
+		arg++
+
+		if column != "" {
+			q.setSpanParameter(column, value)
+		}
+		if inline {
+			result.WriteString(literal(value))
+			continue
+		}
+
+		(*itemNum)++
+		*queryData = append(*queryData, value)
+		*columns = append(*columns, column)
+		result.WriteString(fmt.Sprintf("$%d", *itemNum))
+	}
+
+	if arg != len(expr.Args) {
+		return result.String(), fmt.Errorf("%w: %q has %d placeholders and %d args", ErrExprArgs, expr.SQL, arg, len(expr.Args))
+	}
+
+	return result.String(), nil
+}
+func (q *QueryBuilder) renderExprs(exprs []Expr, itemNum *int, queryData *[]interface{}, columns *[]string) ([]string, error) {
+	rendered := make([]string, 0, len(exprs))
+
+	var errs []error
+	for _, item := range exprs {
+		sql, err := q.renderExpr(item, "", false, itemNum, queryData, columns)
+		rendered = append(rendered, sql)
+		errs = append(errs, err)
+	}
+
+	return rendered, errors.Join(errs...)
+}
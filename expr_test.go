@@ -0,0 +1,126 @@
+package query
+
+import (
+	"testing"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpr(t *testing.T) {
+	data := []struct {
+		title  string
+		render func() (string, []interface{})
+		result string
+		args   []interface{}
+	}{
+		{
+			title: "Test Update Values",
+			render: NewQueryBuilder().From("users").
+				Values(
+					Value{Column: "name", Val: "Mark"},
+					Value{Column: "updated_at", Val: Raw("NOW()")},
+					Value{Column: "logins", Val: Raw("logins + ?", 1)},
+				).
+				WhereAnd(Where{Column: "id", Type: "=", Val: 7}).
+				ToUpdateQuery,
+			result: `UPDATE "users" SET name = $1, updated_at = NOW(), logins = logins + $2 WHERE (id = $3)`,
+			args:   []interface{}{"Mark", 1, 7},
+		},
+		{
+			title: "Test Select Sequence",
+			render: NewQueryBuilder().From("users", "u").
+				Select("u.id").
+				SelectExpr(Raw("similarity(u.name, ?) AS score", "mark")).
+				Join(Join{Table: "phones", As: "p", On: "p.user_id = u.id"}).
+				WhereAnd(
+					Where{ColumnExpr: Raw("lower(u.name)"), Type: "=", Val: "mark"},
+					Where{Column: "u.created_at", Type: ">", Val: Raw("NOW() - ?::interval", "1 day")},
+					Where{ColumnExpr: Raw("u.tsv @@ plainto_tsquery(?)", "mark")},
+				).
+				GroupBy("u.id").
+				GroupByExpr(Raw("date_trunc(?, u.created_at)", "month")).
+				OrderBy(OrderBy{ColumnExpr: Raw("similarity(u.name, ?)", "mark"), Type: "desc"}).
+				ToSelectSql,
+			result: `SELECT u.id, similarity(u.name, $1) AS score FROM "users" AS "u" INNER JOIN "phones" AS "p" ON p.user_id = u.id WHERE (lower(u.name) = $2 AND u.created_at > NOW() - $3::interval AND u.tsv @@ plainto_tsquery($4)) GROUP BY u.id, date_trunc($5, u.created_at) ORDER BY similarity(u.name, $6) DESC`,
+			args:   []interface{}{"mark", "mark", "1 day", "mark", "month", "mark"},
+		},
+		{
+			title: "Test Tenant Sequence",
+			render: NewQueryBuilder(Tenant("tenant_id", 7)).From("users", "u").
+				SelectExpr(Raw("u.id = ? AS mine", 3)).
+				WhereAnd(Where{Column: "u.active", Type: "=", Val: true}).
+				ToSelectSql,
+			result: `SELECT u.id = $1 AS mine FROM "users" AS "u" WHERE "u".tenant_id = $2 AND ((u.active = $3))`,
+			args:   []interface{}{3, 7, true},
+		},
+		{
+			title: "Test Question Marks",
+			render: NewQueryBuilder().From("users").
+				Select("data ? 'key' AS has_key").
+				WhereAnd(Where{ColumnExpr: Raw("data ?? ? AND note <> '?'", "key")}).
+				ToSelectSql,
+			result: `SELECT data ? 'key' AS has_key FROM "users" WHERE (data ? $1 AND note <> '?')`,
+			args:   []interface{}{"key"},
+		},
+		{
+			title: "Test Question Marks In Literals",
+			render: NewQueryBuilder().From("users").
+				Select("'what?' AS q", `"a?" AS b`, "data ? 'key' AS has_key").
+				GroupBy("'what?'").
+				ToSelectSql,
+			result: `SELECT 'what?' AS q, "a?" AS b, data ? 'key' AS has_key FROM "users" GROUP BY 'what?'`,
+			args:   []interface{}{},
+		},
+		{
+			title: "Test Parse Where False Escape",
+			render: NewQueryBuilder(ParseWhere(false)).From("users").
+				SelectExpr(Raw("? AS label", "x'y")).
+				WhereAnd(Where{Column: "name", Type: "=", Val: Raw("lower(?)", "O'Brien")}).
+				ToSelectSql,
+			result: `SELECT $1 AS label FROM "users" WHERE (name = lower('O''Brien'))`,
+			args:   []interface{}{"x'y"},
+		},
+		{
+			title: "Test Parse Where False Update",
+			render: NewQueryBuilder(ParseWhere(false)).From("t").
+				Values(Value{Column: "a", Val: Raw("?", "x'y")}).
+				ToUpdateQuery,
+			result: `UPDATE "t" SET a = $1`,
+			args:   []interface{}{"x'y"},
+		},
+		{
+			title:  "Test Parse Where False",
+			render: NewQueryBuilder(ParseWhere(false)).From("users").WhereAnd(Where{Column: "name", Type: "=", Val: Raw("lower(?)", "Mark")}).ToSelectSql,
+			result: `SELECT * FROM "users" WHERE (name = lower('Mark'))`,
+			args:   []interface{}{},
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.title, func(t *testing.T) {
+			query, args := item.render()
+
+			assert.Equal(t, item.result, query)
+			assert.Equal(t, item.args, args)
+
+			_, err := pg_query.Parse(query)
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("Validate Args Mismatch", func(t *testing.T) {
+		qb := NewQueryBuilder().From("users").WhereAnd(Where{Column: "age", Type: "BETWEEN", Val: Raw("? AND ?", 18)})
+
+		query, args := qb.ToSelectSql()
+
+		assert.Equal(t, `SELECT * FROM "users" WHERE (age BETWEEN $1 AND NULL)`, query)
+		assert.Equal(t, []interface{}{18}, args)
+		assert.ErrorIs(t, qb.Err(), ErrExprArgs)
+
+		qb = NewQueryBuilder().From("users").Values(Value{Column: "logins", Val: Raw("logins + 1", 1)})
+
+		qb.ToUpdateQuery()
+		assert.ErrorIs(t, qb.Err(), ErrExprArgs)
+	})
+}
@@ -0,0 +1,81 @@
+package filter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	query "github.com/MMortari/go-query-builder"
+)
+
+// DecodeJSON lê um documento de filtro em JSON e o converte em uma condição do QueryBuilder.
+//
+// Formato aceito, onde grupos podem ser aninhados até MaxDepth níveis:
+//
+//	{"and": [
+//	    {"field": "age", "op": "gt", "value": 18},
+//	    {"or": [
+//	        {"field": "status", "op": "in", "value": ["active", "pending"]},
+//	        {"field": "deleted_at", "op": "null", "value": true}
+//	    ]}
+//	]}
+//
+// Quando op é omitido, eq é utilizado. Documentos maiores que MaxBytes, chaves desconhecidas e campos fora do Schema
+// são rejeitados; o retorno é do tipo Errors.
+func (s Schema) DecodeJSON(r io.Reader) (query.Where, error) {
+	limit := int64(s.maxBytes())
+
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return query.Where{}, err
+	}
+	if int64(len(data)) > limit {
+		return query.Where{}, Errors{{Code: CodeLimitExceeded, Message: fmt.Sprintf("document is larger than %d bytes", limit)}}
+	}
+
+	var node Node
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&node); err != nil {
+		return query.Where{}, Errors{{Code: CodeInvalidSyntax, Message: err.Error()}}
+	}
+	if decoder.More() {
+		return query.Where{}, Errors{{Code: CodeInvalidSyntax, Message: "unexpected data after the document"}}
+	}
+
+	return s.Where(node)
+}
+
+// ParseJSON executa DecodeJSON e retorna qb com a condição resultante adicionada via WhereAnd. Quando o documento
+// é inválido, qb é retornado sem alterações.
+//
+// Exemplo de uso:
+//
+//	qb, err := schema.ParseJSON(r.Body, query.NewQueryBuilder().From("users", "u"))
+//	if err != nil {
+//	    var errs filter.Errors
+//	    errors.As(err, &errs) // 400 Bad Request
+//	}
+func (s Schema) ParseJSON(r io.Reader, qb *query.QueryBuilder) (*query.QueryBuilder, error) {
+	where, err := s.DecodeJSON(r)
+	if err != nil {
+		return qb, err
+	}
+
+	return qb.WhereAnd(where), nil
+}
+
+// EncodeJSON converte uma condição do QueryBuilder no documento aceito por DecodeJSON, utilizando os nomes públicos
+// dos campos do Schema.
+func (s Schema) EncodeJSON(where query.Where) ([]byte, error) {
+	node, err := s.Node(where)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(node)
+}
@@ -0,0 +1,175 @@
+package filter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	query "github.com/MMortari/go-query-builder"
+)
+
+type jsonTestCase struct {
+	title  string
+	schema Schema
+	json   string
+	result string
+	args   []interface{}
+	errs   Errors
+}
+
+func TestDecodeJSON(t *testing.T) {
+	limited := schema
+	limited.MaxDepth = 2
+	limited.MaxConditions = 2
+	limited.MaxBytes = 120
+
+	data := []jsonTestCase{
+		{
+			title:  "Test Condition",
+			schema: schema,
+			json:   `{"field": "age", "op": "gt", "value": 18}`,
+			result: `SELECT * FROM "users" AS "u" WHERE (u.age > $1)`,
+			args:   []interface{}{int64(18)},
+		},
+		{
+			title:  "Test Default Operator",
+			schema: schema,
+			json:   `{"field": "status", "value": "active"}`,
+			result: `SELECT * FROM "users" AS "u" WHERE (u.status = $1)`,
+			args:   []interface{}{"active"},
+		},
+		{
+			title:  "Test Nested Groups",
+			schema: schema,
+			json: `{"and": [
+				{"field": "age", "op": "gte", "value": "18"},
+				{"field": "salary", "op": "gte", "value": 1500},
+				{"or": [
+					{"field": "status", "op": "in", "value": ["active", "pending"]},
+					{"and": [{"field": "deleted_at", "op": "null", "value": false}, {"field": "created_at", "op": "lt", "value": "2025-01-31T10:00:00Z"}]}
+				]}
+			]}`,
+			result: `SELECT * FROM "users" AS "u" WHERE ((u.age >= $1 AND salary >= $2 AND (u.status IN ($3, $4) OR (u.deleted_at IS NOT NULL AND u.created_at < $5))))`,
+			args:   []interface{}{int64(18), float64(1500), "active", "pending", time.Date(2025, 1, 31, 10, 0, 0, 0, time.UTC)},
+		},
+		{
+			title:  "Test Validation Errors",
+			schema: schema,
+			json:   `{"or": [{"field": "email", "value": "a@b.com"}, {"field": "age", "op": "like", "value": 1}, {"field": "age", "value": 1.5}, {"field": "status", "value": 10}, {}, {"field": "age", "value": 1, "or": [{"field": "age", "value": 1}]}]}`,
+			errs: Errors{
+				{Field: "email", Path: "or[0]", Code: CodeUnknownField, Message: "field is not filterable"},
+				{Field: "age", Path: "or[1]", Code: CodeInvalidOperator, Message: `operator "like" is not allowed`},
+				{Field: "age", Path: "or[2]", Code: CodeInvalidValue, Message: `expected an int value, got "1.5"`},
+				{Field: "status", Path: "or[3]", Code: CodeInvalidValue, Message: "expected a string value, got 10"},
+				{Path: "or[4]", Code: CodeInvalidSyntax, Message: "node must have a field or a non-empty group"},
+				{Path: "or[5]", Code: CodeInvalidSyntax, Message: "node must be either an and group, an or group, a not or a condition"},
+			},
+		},
+		{
+			title:  "Test Unknown Key",
+			schema: schema,
+			json:   `{"field": "age", "operator": "gt", "value": 18}`,
+			errs:   Errors{{Code: CodeInvalidSyntax, Message: `json: unknown field "operator"`}},
+		},
+		{
+			title:  "Test Trailing Data",
+			schema: schema,
+			json:   `{"field": "age", "value": 18} {}`,
+			errs:   Errors{{Code: CodeInvalidSyntax, Message: "unexpected data after the document"}},
+		},
+		{
+			title:  "Test Max Depth",
+			schema: limited,
+			json:   `{"or": [{"and": [{"field": "age", "value": 1}]}]}`,
+			errs:   Errors{{Path: "or[0].and[0]", Code: CodeLimitExceeded, Message: "expression is deeper than 2 levels"}},
+		},
+		{
+			title:  "Test Max Conditions",
+			schema: limited,
+			json:   `{"or": [{"field": "age", "value": 1}, {"field": "age", "value": 2}, {"field": "age", "value": 3}]}`,
+			errs:   Errors{{Path: "or[2]", Code: CodeLimitExceeded, Message: "expression has more than 2 conditions"}},
+		},
+		{
+			title:  "Test Max Bytes",
+			schema: limited,
+			json:   `{"or": [{"field": "status", "value": "` + strings.Repeat("a", 120) + `"}]}`,
+			errs:   Errors{{Code: CodeLimitExceeded, Message: "document is larger than 120 bytes"}},
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.title, func(t *testing.T) {
+			qb := query.NewQueryBuilder().From("users", "u")
+			qb, err := item.schema.ParseJSON(strings.NewReader(item.json), qb)
+
+			if item.errs != nil {
+				var errs Errors
+				require.ErrorAs(t, err, &errs)
+				assert.Equal(t, item.errs, errs)
+				return
+			}
+			require.NoError(t, err)
+
+			sql, args := qb.ToSelectSql()
+			assert.Equal(t, item.result, sql)
+			assert.Equal(t, item.args, args)
+
+			_, err = pg_query.Parse(sql)
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("Test Immutable", func(t *testing.T) {
+		base := query.NewQueryBuilder(query.Immutable()).From("users", "u")
+
+		qb, err := schema.ParseJSON(strings.NewReader(`{"field": "status", "value": "active"}`), base)
+		require.NoError(t, err)
+
+		sql, args := qb.ToSelectSql()
+		assert.Equal(t, `SELECT * FROM "users" AS "u" WHERE (u.status = $1)`, sql)
+		assert.Equal(t, []interface{}{"active"}, args)
+
+		sql, _ = base.ToSelectSql()
+		assert.Equal(t, `SELECT * FROM "users" AS "u"`, sql)
+	})
+}
+
+func TestEncodeJSON(t *testing.T) {
+	where := query.Where{And: []query.Where{
+		{Column: "u.age", Type: ">=", Val: int64(18)},
+		{Or: []query.Where{
+			{Column: "u.status", Type: "in", Val: []string{"active", "pending"}},
+			{Column: "u.deleted_at", Type: "IS NULL"},
+			{Column: "u.active", Type: "=", Val: false},
+		}},
+	}}
+
+	data, err := schema.EncodeJSON(where)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"and": [
+		{"field": "age", "op": "gte", "value": 18},
+		{"or": [
+			{"field": "status", "op": "in", "value": ["active", "pending"]},
+			{"field": "deleted_at", "op": "null", "value": true},
+			{"field": "active", "op": "eq", "value": false}
+		]}
+	]}`, string(data))
+
+	decoded, err := schema.DecodeJSON(strings.NewReader(string(data)))
+	require.NoError(t, err)
+
+	expected, _ := query.NewQueryBuilder().From("users").WhereAnd(where).ToSelectSql()
+	result, _ := query.NewQueryBuilder().From("users").WhereAnd(decoded).ToSelectSql()
+	assert.Equal(t, expected, result)
+
+	_, err = schema.EncodeJSON(query.Where{Column: "u.password", Type: "=", Val: "x"})
+	assert.ErrorContains(t, err, `column "u.password" is not in the schema`)
+
+	_, err = schema.EncodeJSON(query.Where{Column: "u.age", Type: "between", Val: []int{1, 2}})
+	assert.ErrorContains(t, err, `operator "between" has no filter representation`)
+}
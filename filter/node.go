@@ -0,0 +1,219 @@
+package filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	query "github.com/MMortari/go-query-builder"
+)
+
+// Node é a representação de uma expressão de filtro, compartilhada pelos formatos aceitos por este pacote.
+//
+// Um Node é um grupo (And ou Or), uma negação (Not) ou uma condição (Field, Op e Value), nunca mais de um.
+type Node struct {
+	And []Node `json:"and,omitempty"`
+	Or  []Node `json:"or,omitempty"`
+	Not *Node  `json:"not,omitempty"`
+
+	Field string   `json:"field,omitempty"`
+	Op    Operator `json:"op,omitempty"`
+	// Value é um valor único, ou uma lista de valores para In e NotIn.
+	Value any `json:"value"`
+}
+
+func (n Node) MarshalJSON() ([]byte, error) {
+	type node Node
+
+	if n.Field == "" {
+		return json.Marshal(struct {
+			And []Node `json:"and,omitempty"`
+			Or  []Node `json:"or,omitempty"`
+			Not *Node  `json:"not,omitempty"`
+		}{And: n.And, Or: n.Or, Not: n.Not})
+	}
+
+	return json.Marshal(node(n))
+}
+
+// Where valida node no Schema e o converte em uma condição do QueryBuilder, pronta para ser utilizada em WhereAnd.
+//
+// Os campos precisam estar declarados no Schema, os operadores precisam ser permitidos para o campo e os valores são
+// convertidos para o tipo do campo. A profundidade e o número de condições são limitados por MaxDepth e
+// MaxConditions. Em caso de erro, o retorno é do tipo Errors.
+func (s Schema) Where(node Node) (query.Where, error) {
+	c := compiler{schema: s}
+
+	where := c.compile(node, "", 1)
+	if len(c.errs) != 0 {
+		return query.Where{}, c.errs
+	}
+
+	return where, nil
+}
+
+type compiler struct {
+	schema     Schema
+	conditions int
+	errs       Errors
+}
+
+func (c *compiler) compile(node Node, path string, depth int) query.Where {
+	if depth > c.schema.maxDepth() {
+		c.errs = append(c.errs, FieldError{Path: path, Code: CodeLimitExceeded, Message: fmt.Sprintf("expression is deeper than %d levels", c.schema.maxDepth())})
+		return query.Where{}
+	}
+
+	kinds := 0
+	for _, item := range []bool{len(node.And) != 0, len(node.Or) != 0, node.Not != nil, node.Field != ""} {
+		if item {
+			kinds++
+		}
+	}
+
+	switch {
+	case kinds > 1:
+		c.errs = append(c.errs, FieldError{Path: path, Code: CodeInvalidSyntax, Message: "node must be either an and group, an or group, a not or a condition"})
+	case node.Not != nil:
+		where := c.compile(*node.Not, join(path, "not"), depth+1)
+		where.Not = !where.Not
+		return where
+	case len(node.And) != 0:
+		return query.Where{And: c.compileGroup(node.And, join(path, "and"), depth)}
+	case len(node.Or) != 0:
+		return query.Where{Or: c.compileGroup(node.Or, join(path, "or"), depth)}
+	case node.Field == "":
+		c.errs = append(c.errs, FieldError{Path: path, Code: CodeInvalidSyntax, Message: "node must have a field or a non-empty group"})
+	default:
+		c.conditions++
+		if c.conditions == c.schema.maxConditions()+1 {
+			c.errs = append(c.errs, FieldError{Path: path, Code: CodeLimitExceeded, Message: fmt.Sprintf("expression has more than %d conditions", c.schema.maxConditions())})
+		}
+
+		op := node.Op
+		if op == "" {
+			op = Eq
+		}
+
+		where, err := c.schema.condition(node.Field, op, nodeValues(op, node.Value))
+		if err != nil {
+			err.Path = path
+			c.errs = append(c.errs, *err)
+		}
+		return where
+	}
+
+	return query.Where{}
+}
+func (c *compiler) compileGroup(nodes []Node, path string, depth int) []query.Where {
+	wheres := make([]query.Where, 0, len(nodes))
+	for i, item := range nodes {
+		wheres = append(wheres, c.compile(item, fmt.Sprintf("%s[%d]", path, i), depth+1))
+	}
+
+	return wheres
+}
+
+// Node converte uma condição do QueryBuilder de volta para Node, utilizando os nomes públicos dos campos do Schema.
+//
+// É o caminho inverso de Where e retorna erro para colunas fora do Schema ou operadores sem representação.
+func (s Schema) Node(where query.Where) (Node, error) {
+	if where.Not {
+		where.Not = false
+
+		node, err := s.Node(where)
+		if err != nil {
+			return Node{}, err
+		}
+		return Node{Not: &node}, nil
+	}
+	if len(where.And) != 0 && len(where.Or) != 0 {
+		return s.Node(query.Where{And: []query.Where{{And: where.And}, {Or: where.Or}}})
+	}
+	if len(where.And) != 0 || len(where.Or) != 0 {
+		items := where.And
+		if len(items) == 0 {
+			items = where.Or
+		}
+
+		nodes := make([]Node, 0, len(items))
+		for _, item := range items {
+			node, err := s.Node(item)
+			if err != nil {
+				return Node{}, err
+			}
+			nodes = append(nodes, node)
+		}
+
+		if len(where.And) != 0 {
+			return Node{And: nodes}, nil
+		}
+		return Node{Or: nodes}, nil
+	}
+
+	name, ok := s.fieldName(where.Column)
+	if !ok {
+		return Node{}, fmt.Errorf("filter: column %q is not in the schema", where.Column)
+	}
+
+	Type := strings.ToUpper(where.Type)
+
+	switch Type {
+	case "IS NULL":
+		return Node{Field: name, Op: IsNull, Value: true}, nil
+	case "IS NOT NULL":
+		return Node{Field: name, Op: IsNull, Value: false}, nil
+	}
+
+	for op, sql := range operatorSQL {
+		if sql != Type {
+			continue
+		}
+
+		if op == In || op == NotIn {
+			return Node{Field: name, Op: op, Value: toSlice(where.Val)}, nil
+		}
+		return Node{Field: name, Op: op, Value: where.Val}, nil
+	}
+
+	return Node{}, fmt.Errorf("filter: operator %q has no filter representation", where.Type)
+}
+
+func (s Schema) fieldName(column string) (string, bool) {
+	for name, field := range s.Fields {
+		if field.column(name) == column {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+func nodeValues(op Operator, value any) []any {
+	if op == In || op == NotIn {
+		return toSlice(value)
+	}
+
+	return []any{value}
+}
+func toSlice(value any) []any {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice {
+		return []any{value}
+	}
+
+	values := make([]any, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		values = append(values, v.Index(i).Interface())
+	}
+
+	return values
+}
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
@@ -0,0 +1,507 @@
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	query "github.com/MMortari/go-query-builder"
+)
+
+// Query options do OData aceitas por ParseOData.
+const (
+	ODataFilter  = "$filter"
+	ODataOrderBy = "$orderby"
+	ODataTop     = "$top"
+	ODataSkip    = "$skip"
+)
+
+var odataOperators = map[string]Operator{
+	"eq": Eq,
+	"ne": Ne,
+	"gt": Gt,
+	"ge": Gte,
+	"lt": Lt,
+	"le": Lte,
+}
+
+var odataFunctions = map[string]Operator{
+	"contains":   Contains,
+	"startswith": StartsWith,
+	"endswith":   EndsWith,
+}
+
+// DecodeODataFilter interpreta uma expressão $filter do OData e a converte em uma condição do QueryBuilder.
+//
+// Sintaxe aceita:
+//
+//	name eq 'John' and age gt 18               operadores eq, ne, gt, ge, lt e le
+//	status eq 'a' or not (age lt 18)           and, or e not, com parênteses
+//	status in ('active', 'pending')            listas para in
+//	contains(name, 'jo')                       contains, startswith e endswith
+//	deleted_at eq null                         eq null / ne null geram IS NULL / IS NOT NULL
+//
+// Strings são delimitadas por aspas simples, com duas aspas seguidas para representar uma aspa no valor. Números,
+// booleanos e datas (ex: 2024-01-31T10:00:00Z) podem ser informados sem aspas. Funções e operadores fora desta lista
+// são rejeitados com erro. As mesmas validações de Where são aplicadas e o retorno de erro é do tipo Errors.
+func (s Schema) DecodeODataFilter(expr string) (query.Where, error) {
+	if len(expr) > s.maxBytes() {
+		return query.Where{}, Errors{{Field: ODataFilter, Code: CodeLimitExceeded, Message: fmt.Sprintf("expression is larger than %d bytes", s.maxBytes())}}
+	}
+
+	tokens, err := odataTokenize(expr)
+	if err != nil {
+		return query.Where{}, Errors{*err}
+	}
+
+	p := odataParser{tokens: tokens, maxDepth: s.maxDepth()}
+
+	node, err := p.parse()
+	if err != nil {
+		return query.Where{}, Errors{*err}
+	}
+
+	return s.Where(node)
+}
+
+// ParseOData interpreta as query options $filter, $orderby, $top e $skip e retorna qb com elas aplicadas via
+// WhereAnd, OrderBy, Limit e Offset.
+//
+// Formato aceito:
+//
+//	$filter=status eq 'active' and age ge 18   veja DecodeODataFilter
+//	$orderby=name, created_at desc             ordenação, asc quando a direção não é informada
+//	$top=20&$skip=40                           limite e deslocamento
+//
+// Quando $top não é informado, DefaultPageSize é utilizado como limite, e $top maior que MaxPageSize é rejeitado.
+// Parâmetros sem `$` são ignorados e as demais query options do OData (ex: $select, $expand) são rejeitadas.
+// Quando algum parâmetro é inválido, qb é retornado sem alterações e o erro é do tipo Errors.
+//
+// Exemplo de uso:
+//
+//	qb, err := schema.ParseOData(r.URL.Query(), query.NewQueryBuilder().From("users", "u"))
+func (s Schema) ParseOData(values url.Values, qb *query.QueryBuilder) (*query.QueryBuilder, error) {
+	var (
+		errs     Errors
+		wheres   []query.Where
+		orderBys []query.OrderBy
+	)
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		switch {
+		case !strings.HasPrefix(key, "$"):
+		case key == ODataFilter, key == ODataOrderBy, key == ODataTop, key == ODataSkip:
+			if len(values[key]) > 1 {
+				errs = append(errs, FieldError{Field: key, Code: CodeInvalidSyntax, Message: "query option must be informed only once"})
+			}
+		default:
+			errs = append(errs, FieldError{Field: key, Code: CodeInvalidSyntax, Message: "query option is not supported"})
+		}
+	}
+
+	if raw := values.Get(ODataFilter); raw != "" {
+		where, err := s.DecodeODataFilter(raw)
+		var filterErrs Errors
+		switch {
+		case errors.As(err, &filterErrs):
+			errs = append(errs, filterErrs...)
+		case err != nil:
+			errs = append(errs, FieldError{Field: ODataFilter, Code: CodeInvalidSyntax, Message: err.Error()})
+		default:
+			wheres = append(wheres, where)
+		}
+	}
+
+	for _, item := range strings.Split(values.Get(ODataOrderBy), ",") {
+		parts := strings.Fields(item)
+		if len(parts) == 0 {
+			continue
+		}
+
+		desc := false
+		if len(parts) == 2 {
+			switch strings.ToLower(parts[1]) {
+			case "asc":
+			case "desc":
+				desc = true
+			default:
+				parts = nil
+			}
+		}
+		if len(parts) != 1 && len(parts) != 2 {
+			errs = append(errs, FieldError{Field: ODataOrderBy, Code: CodeInvalidSyntax, Message: fmt.Sprintf("expected a field and an optional asc or desc, got %q", strings.TrimSpace(item))})
+			continue
+		}
+
+		orderBy, err := s.orderBy(parts[0], desc)
+		if err != nil {
+			errs = append(errs, *err)
+			continue
+		}
+		orderBys = append(orderBys, orderBy)
+	}
+
+	top, skip, pageErrs := s.odataPagination(values)
+	errs = append(errs, pageErrs...)
+
+	if len(errs) != 0 {
+		return qb, errs
+	}
+
+	if len(wheres) != 0 {
+		qb = qb.WhereAnd(wheres...)
+	}
+	for _, item := range orderBys {
+		qb = qb.OrderBy(item)
+	}
+	if top != 0 {
+		qb = qb.Limit(top)
+	}
+	if skip != 0 {
+		qb = qb.Offset(skip)
+	}
+
+	return qb, nil
+}
+
+func (s Schema) odataPagination(values url.Values) (top int, skip int, errs Errors) {
+	top = s.DefaultPageSize
+
+	if raw := values.Get(ODataTop); raw != "" {
+		value, err := strconv.Atoi(raw)
+		switch {
+		case err != nil || value < 1:
+			errs = append(errs, FieldError{Field: ODataTop, Code: CodeInvalidPage, Message: "must be a positive integer"})
+		case s.MaxPageSize != 0 && value > s.MaxPageSize:
+			errs = append(errs, FieldError{Field: ODataTop, Code: CodeInvalidPage, Message: "must be at most " + strconv.Itoa(s.MaxPageSize)})
+		}
+		top = value
+	}
+
+	if raw := values.Get(ODataSkip); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 0 {
+			errs = append(errs, FieldError{Field: ODataSkip, Code: CodeInvalidPage, Message: "must be a non-negative integer"})
+		}
+		skip = value
+	}
+
+	return top, skip, errs
+}
+
+type odataTokenKind int
+
+const (
+	odataEOF odataTokenKind = iota
+	odataWord
+	odataString
+	odataOpen
+	odataClose
+	odataComma
+)
+
+type odataToken struct {
+	kind odataTokenKind
+	text string
+	pos  int
+}
+
+func odataTokenize(expr string) ([]odataToken, *FieldError) {
+	tokens := make([]odataToken, 0)
+
+	for pos := 0; pos < len(expr); {
+		c := expr[pos]
+
+		switch c {
+		case ' ', '\t':
+			pos++
+		case '(':
+			tokens = append(tokens, odataToken{kind: odataOpen, text: "(", pos: pos})
+			pos++
+		case ')':
+			tokens = append(tokens, odataToken{kind: odataClose, text: ")", pos: pos})
+			pos++
+		case ',':
+			tokens = append(tokens, odataToken{kind: odataComma, text: ",", pos: pos})
+			pos++
+		case '\'':
+			start := pos
+			pos++
+
+			var value strings.Builder
+			for {
+				if pos >= len(expr) {
+					return nil, odataErrorf(start, "unterminated string literal")
+				}
+				if expr[pos] == '\'' {
+					// '' representa uma aspa simples dentro do valor
+					if pos+1 < len(expr) && expr[pos+1] == '\'' {
+						value.WriteByte('\'')
+						pos += 2
+						continue
+					}
+					pos++
+					break
+				}
+				value.WriteByte(expr[pos])
+				pos++
+			}
+
+			tokens = append(tokens, odataToken{kind: odataString, text: value.String(), pos: start})
+		default:
+			start := pos
+			for pos < len(expr) && !strings.ContainsRune(" \t(),'", rune(expr[pos])) {
+				pos++
+			}
+			tokens = append(tokens, odataToken{kind: odataWord, text: expr[start:pos], pos: start})
+		}
+	}
+
+	return append(tokens, odataToken{kind: odataEOF, pos: len(expr)}), nil
+}
+
+type odataParser struct {
+	tokens   []odataToken
+	pos      int
+	depth    int
+	maxDepth int
+}
+
+func (p *odataParser) parse() (Node, *FieldError) {
+	node, err := p.or()
+	if err != nil {
+		return Node{}, err
+	}
+
+	if next := p.peek(); next.kind != odataEOF {
+		return Node{}, odataErrorf(next.pos, "unexpected %q", next.text)
+	}
+
+	return node, nil
+}
+
+// or = and { "or" and }
+func (p *odataParser) or() (Node, *FieldError) {
+	return p.list(p.and, "or", func(nodes []Node) Node { return Node{Or: nodes} })
+}
+
+// and = unary { "and" unary }
+func (p *odataParser) and() (Node, *FieldError) {
+	return p.list(p.unary, "and", func(nodes []Node) Node { return Node{And: nodes} })
+}
+
+func (p *odataParser) list(item func() (Node, *FieldError), keyword string, group func([]Node) Node) (Node, *FieldError) {
+	first, err := item()
+	if err != nil {
+		return Node{}, err
+	}
+
+	nodes := []Node{first}
+	for p.keyword(keyword) {
+		next, err := item()
+		if err != nil {
+			return Node{}, err
+		}
+		nodes = append(nodes, next)
+	}
+
+	if len(nodes) == 1 {
+		return first, nil
+	}
+
+	return group(nodes), nil
+}
+
+// unary = "not" unary | primary
+func (p *odataParser) unary() (Node, *FieldError) {
+	if !p.keyword("not") {
+		return p.primary()
+	}
+
+	node, err := p.unary()
+	if err != nil {
+		return Node{}, err
+	}
+
+	return Node{Not: &node}, nil
+}
+
+// primary = "(" or ")" | function "(" field "," string ")" | field operator value | field "in" "(" value { "," value } ")"
+func (p *odataParser) primary() (Node, *FieldError) {
+	token := p.next()
+
+	switch {
+	case token.kind == odataOpen:
+		p.depth++
+		if p.depth > p.maxDepth {
+			return Node{}, &FieldError{Field: ODataFilter, Code: CodeLimitExceeded, Message: fmt.Sprintf("expression is deeper than %d levels", p.maxDepth)}
+		}
+
+		node, err := p.or()
+		if err != nil {
+			return Node{}, err
+		}
+		if err := p.expect(odataClose, "')'"); err != nil {
+			return Node{}, err
+		}
+		p.depth--
+
+		return node, nil
+	case token.kind != odataWord:
+		return Node{}, odataErrorf(token.pos, "expected a field or a function")
+	case p.peek().kind == odataOpen:
+		return p.function(token)
+	}
+
+	operator := p.next()
+	if operator.kind != odataWord {
+		return Node{}, odataErrorf(operator.pos, "expected a comparison operator")
+	}
+
+	name := strings.ToLower(operator.text)
+	if name == "in" {
+		values, err := p.values()
+		if err != nil {
+			return Node{}, err
+		}
+		return Node{Field: token.text, Op: In, Value: values}, nil
+	}
+
+	op, ok := odataOperators[name]
+	if !ok {
+		return Node{}, &FieldError{Field: token.text, Code: CodeInvalidOperator, Message: fmt.Sprintf("operator %q is not supported at position %d", operator.text, operator.pos)}
+	}
+
+	value, isNull, err := p.value()
+	if err != nil {
+		return Node{}, err
+	}
+
+	if isNull {
+		switch op {
+		case Eq:
+			return Node{Field: token.text, Op: IsNull, Value: true}, nil
+		case Ne:
+			return Node{Field: token.text, Op: IsNull, Value: false}, nil
+		default:
+			return Node{}, odataErrorf(operator.pos, "null can only be compared with eq or ne")
+		}
+	}
+
+	return Node{Field: token.text, Op: op, Value: value}, nil
+}
+
+func (p *odataParser) function(name odataToken) (Node, *FieldError) {
+	op, ok := odataFunctions[strings.ToLower(name.text)]
+	if !ok {
+		return Node{}, &FieldError{Field: ODataFilter, Code: CodeInvalidOperator, Message: fmt.Sprintf("function %q is not supported at position %d", name.text, name.pos)}
+	}
+	p.next()
+
+	field := p.next()
+	if field.kind != odataWord {
+		return Node{}, odataErrorf(field.pos, "expected a field")
+	}
+	if err := p.expect(odataComma, "','"); err != nil {
+		return Node{}, err
+	}
+
+	value := p.next()
+	if value.kind != odataString {
+		return Node{}, odataErrorf(value.pos, "expected a string literal")
+	}
+	if err := p.expect(odataClose, "')'"); err != nil {
+		return Node{}, err
+	}
+
+	return Node{Field: field.text, Op: op, Value: value.text}, nil
+}
+
+// values = "(" value { "," value } ")"
+func (p *odataParser) values() ([]any, *FieldError) {
+	if err := p.expect(odataOpen, "'('"); err != nil {
+		return nil, err
+	}
+
+	values := make([]any, 0)
+	for {
+		start := p.peek().pos
+
+		value, isNull, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		if isNull {
+			return nil, odataErrorf(start, "null is not allowed in a list")
+		}
+		values = append(values, value)
+
+		token := p.next()
+		switch token.kind {
+		case odataComma:
+		case odataClose:
+			return values, nil
+		default:
+			return nil, odataErrorf(token.pos, "expected ',' or ')'")
+		}
+	}
+}
+
+// value lê um literal. Palavras sem aspas são repassadas como texto e convertidas para o tipo do campo pelo Schema.
+func (p *odataParser) value() (value string, isNull bool, err *FieldError) {
+	token := p.next()
+
+	switch token.kind {
+	case odataString:
+		return token.text, false, nil
+	case odataWord:
+		return token.text, token.text == "null", nil
+	default:
+		return "", false, odataErrorf(token.pos, "expected a value")
+	}
+}
+
+// keyword consome a palavra-chave informada, sem diferenciar maiúsculas e minúsculas.
+func (p *odataParser) keyword(keyword string) bool {
+	token := p.peek()
+	if token.kind != odataWord || !strings.EqualFold(token.text, keyword) {
+		return false
+	}
+
+	p.pos++
+	return true
+}
+
+func (p *odataParser) expect(kind odataTokenKind, description string) *FieldError {
+	token := p.next()
+	if token.kind != kind {
+		return odataErrorf(token.pos, "expected %s", description)
+	}
+
+	return nil
+}
+
+func (p *odataParser) peek() odataToken {
+	return p.tokens[p.pos]
+}
+func (p *odataParser) next() odataToken {
+	token := p.tokens[p.pos]
+	if token.kind != odataEOF {
+		p.pos++
+	}
+
+	return token
+}
+
+func odataErrorf(pos int, format string, args ...any) *FieldError {
+	return &FieldError{Field: ODataFilter, Code: CodeInvalidSyntax, Message: fmt.Sprintf(format, args...) + fmt.Sprintf(" at position %d", pos)}
+}
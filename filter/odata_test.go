@@ -0,0 +1,177 @@
+package filter
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	query "github.com/MMortari/go-query-builder"
+)
+
+type odataTestCase struct {
+	title  string
+	query  url.Values
+	result string
+	args   []interface{}
+	errs   Errors
+}
+
+func TestParseOData(t *testing.T) {
+	odataSchema := Schema{Fields: map[string]Field{}, DefaultPageSize: schema.DefaultPageSize, MaxPageSize: schema.MaxPageSize}
+	for name, field := range schema.Fields {
+		odataSchema.Fields[name] = field
+	}
+	odataSchema.Fields["name"] = Field{Column: "u.name", Operators: []Operator{Eq, Contains, StartsWith, EndsWith}, Sortable: true}
+
+	data := []odataTestCase{
+		{
+			title:  "Test Empty",
+			query:  url.Values{},
+			result: `SELECT * FROM "users" AS "u" LIMIT 10`,
+			args:   []interface{}{},
+		},
+		{
+			title:  "Test Comparison",
+			query:  url.Values{"$filter": {"name eq 'John' and age gt 18 and age le 65"}},
+			result: `SELECT * FROM "users" AS "u" WHERE ((u.name = $1 AND u.age > $2 AND u.age <= $3)) LIMIT 10`,
+			args:   []interface{}{"John", int64(18), int64(65)},
+		},
+		{
+			title:  "Test And Or Not Precedence",
+			query:  url.Values{"$filter": {"status ne 'blocked' and not (age lt 18 or active eq false) or name eq 'admin'"}},
+			result: `SELECT * FROM "users" AS "u" WHERE (((u.status != $1 AND NOT (u.age < $2 OR u.active = $3)) OR u.name = $4)) LIMIT 10`,
+			args:   []interface{}{"blocked", int64(18), false, "admin"},
+		},
+		{
+			title:  "Test Double Not",
+			query:  url.Values{"$filter": {"not not name eq 'John'"}},
+			result: `SELECT * FROM "users" AS "u" WHERE (u.name = $1) LIMIT 10`,
+			args:   []interface{}{"John"},
+		},
+		{
+			title:  "Test Functions",
+			query:  url.Values{"$filter": {"contains(name, '50%_off') or startswith(name,'O''Brien') or endswith(name, 'son')"}},
+			result: `SELECT * FROM "users" AS "u" WHERE ((u.name LIKE $1 OR u.name LIKE $2 OR u.name LIKE $3)) LIMIT 10`,
+			args:   []interface{}{`%50\%\_off%`, "O'Brien%", "%son"},
+		},
+		{
+			title:  "Test In And Null",
+			query:  url.Values{"$filter": {"status in ('active', 'pending') and deleted_at ne null and created_at ge 2025-01-31T10:00:00Z"}},
+			result: `SELECT * FROM "users" AS "u" WHERE ((u.status IN ($1, $2) AND u.deleted_at IS NOT NULL AND u.created_at >= $3)) LIMIT 10`,
+		},
+		{
+			title:  "Test OrderBy Top Skip",
+			query:  url.Values{"$orderby": {"name, created_at desc"}, "$top": {"20"}, "$skip": {"40"}, "other": {"ignored"}},
+			result: `SELECT * FROM "users" AS "u" ORDER BY u.name ASC, u.created_at DESC LIMIT 20 OFFSET 40`,
+			args:   []interface{}{},
+		},
+		{
+			title: "Test Unsupported Function",
+			query: url.Values{"$filter": {"tolower(name) eq 'john'"}},
+			errs:  Errors{{Field: "$filter", Code: CodeInvalidOperator, Message: `function "tolower" is not supported at position 0`}},
+		},
+		{
+			title: "Test Unsupported Operator",
+			query: url.Values{"$filter": {"age add 1 eq 2"}},
+			errs:  Errors{{Field: "age", Code: CodeInvalidOperator, Message: `operator "add" is not supported at position 4`}},
+		},
+		{
+			title: "Test Schema Errors",
+			query: url.Values{"$filter": {"password eq 'x' or contains(age, '1')"}},
+			errs: Errors{
+				{Field: "password", Path: "or[0]", Code: CodeUnknownField, Message: "field is not filterable"},
+				{Field: "age", Path: "or[1]", Code: CodeInvalidOperator, Message: `operator "contains" is not allowed`},
+			},
+		},
+		{
+			title: "Test Syntax Errors",
+			query: url.Values{"$filter": {"(name eq 'John'"}},
+			errs:  Errors{{Field: "$filter", Code: CodeInvalidSyntax, Message: "expected ')' at position 15"}},
+		},
+		{
+			title: "Test Null Comparison",
+			query: url.Values{"$filter": {"deleted_at gt null"}},
+			errs:  Errors{{Field: "$filter", Code: CodeInvalidSyntax, Message: "null can only be compared with eq or ne at position 11"}},
+		},
+		{
+			title: "Test Unterminated String",
+			query: url.Values{"$filter": {"name eq 'John"}},
+			errs:  Errors{{Field: "$filter", Code: CodeInvalidSyntax, Message: "unterminated string literal at position 8"}},
+		},
+		{
+			title: "Test Max Depth",
+			query: url.Values{"$filter": {strings.Repeat("(", 10) + "name eq 'John'" + strings.Repeat(")", 10)}},
+			errs:  Errors{{Field: "$filter", Code: CodeLimitExceeded, Message: "expression is deeper than 5 levels"}},
+		},
+		{
+			title: "Test Invalid Options",
+			query: url.Values{"$select": {"name"}, "$orderby": {"age desc", "name"}, "$top": {"100"}, "$skip": {"-1"}},
+			errs: Errors{
+				{Field: "$orderby", Code: CodeInvalidSyntax, Message: "query option must be informed only once"},
+				{Field: "$select", Code: CodeInvalidSyntax, Message: "query option is not supported"},
+				{Field: "age", Code: CodeNotSortable, Message: "field is not sortable"},
+				{Field: "$top", Code: CodeInvalidPage, Message: "must be at most 50"},
+				{Field: "$skip", Code: CodeInvalidPage, Message: "must be a non-negative integer"},
+			},
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.title, func(t *testing.T) {
+			qb := query.NewQueryBuilder().From("users", "u")
+			qb, err := odataSchema.ParseOData(item.query, qb)
+
+			if item.errs != nil {
+				var errs Errors
+				require.ErrorAs(t, err, &errs)
+				assert.Equal(t, item.errs, errs)
+
+				sql, _ := qb.ToSelectSql()
+				assert.Equal(t, `SELECT * FROM "users" AS "u"`, sql)
+				return
+			}
+			require.NoError(t, err)
+
+			sql, args := qb.ToSelectSql()
+			assert.Equal(t, item.result, sql)
+			if item.args != nil {
+				assert.Equal(t, item.args, args)
+			}
+
+			_, err = pg_query.Parse(sql)
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("Test Immutable", func(t *testing.T) {
+		base := query.NewQueryBuilder(query.Immutable()).From("users", "u")
+
+		qb, err := odataSchema.ParseOData(url.Values{"$filter": {"status eq 'active'"}, "$orderby": {"name desc"}, "$top": {"5"}, "$skip": {"5"}}, base)
+		require.NoError(t, err)
+
+		sql, args := qb.ToSelectSql()
+		assert.Equal(t, `SELECT * FROM "users" AS "u" WHERE (u.status = $1) ORDER BY u.name DESC LIMIT 5 OFFSET 5`, sql)
+		assert.Equal(t, []interface{}{"active"}, args)
+
+		sql, _ = base.ToSelectSql()
+		assert.Equal(t, `SELECT * FROM "users" AS "u"`, sql)
+	})
+}
+
+func TestODataNot(t *testing.T) {
+	where, err := schema.DecodeODataFilter("not (status eq 'a' or age gt 1)")
+	require.NoError(t, err)
+	assert.True(t, where.Not)
+
+	data, err := schema.EncodeJSON(where)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"not": {"or": [{"field": "status", "op": "eq", "value": "a"}, {"field": "age", "op": "gt", "value": 1}]}}`, string(data))
+
+	decoded, err := schema.DecodeJSON(strings.NewReader(string(data)))
+	require.NoError(t, err)
+	assert.Equal(t, where, decoded)
+}
@@ -0,0 +1,302 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+
+	query "github.com/MMortari/go-query-builder"
+)
+
+var rsqlOperators = map[string]Operator{
+	"==":    Eq,
+	"!=":    Ne,
+	"=lt=":  Lt,
+	"<":     Lt,
+	"=le=":  Lte,
+	"<=":    Lte,
+	"=gt=":  Gt,
+	">":     Gt,
+	"=ge=":  Gte,
+	">=":    Gte,
+	"=in=":  In,
+	"=out=": NotIn,
+}
+
+// DecodeRSQL interpreta uma expressão RSQL/FIQL e a converte em uma condição do QueryBuilder.
+//
+// Sintaxe aceita:
+//
+//	name==John;age=gt=18                 ";" (ou "and") é AND
+//	status==active,status==pending       "," (ou "or") é OR, com precedência menor que AND
+//	(status==a,status==b);age=ge=18      parênteses agrupam
+//	status=in=(a,b);role=out=("x y",z)   listas para =in= e =out=
+//	name=ilike='%jo%';deleted_at=null=true
+//
+// Além dos operadores padrão (==, !=, =lt=, =le=, =gt=, =ge=, <, <=, >, >=, =in=, =out=), qualquer operador
+// do pacote pode ser utilizado na forma `=op=`, ex: `=like=`. As mesmas validações de Where são aplicadas e o
+// retorno de erro é do tipo Errors.
+func (s Schema) DecodeRSQL(expr string) (query.Where, error) {
+	if len(expr) > s.maxBytes() {
+		return query.Where{}, Errors{{Code: CodeLimitExceeded, Message: fmt.Sprintf("expression is larger than %d bytes", s.maxBytes())}}
+	}
+
+	p := rsqlParser{input: expr, maxDepth: s.maxDepth()}
+
+	node, err := p.parse()
+	if err != nil {
+		return query.Where{}, Errors{*err}
+	}
+
+	return s.Where(node)
+}
+
+// ParseRSQL executa DecodeRSQL e retorna qb com a condição resultante adicionada via WhereAnd. Quando a expressão
+// é inválida, qb é retornado sem alterações.
+//
+// Exemplo de uso:
+//
+//	qb, err := schema.ParseRSQL(r.URL.Query().Get("filter"), query.NewQueryBuilder().From("users", "u"))
+func (s Schema) ParseRSQL(expr string, qb *query.QueryBuilder) (*query.QueryBuilder, error) {
+	where, err := s.DecodeRSQL(expr)
+	if err != nil {
+		return qb, err
+	}
+
+	return qb.WhereAnd(where), nil
+}
+
+type rsqlParser struct {
+	input    string
+	pos      int
+	depth    int
+	maxDepth int
+}
+
+func (p *rsqlParser) parse() (Node, *FieldError) {
+	node, err := p.or()
+	if err != nil {
+		return Node{}, err
+	}
+
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return Node{}, p.errorf("unexpected %q", p.input[p.pos])
+	}
+
+	return node, nil
+}
+
+// or = and { ("," | "or") and }
+func (p *rsqlParser) or() (Node, *FieldError) {
+	return p.list(p.and, ',', "or", func(nodes []Node) Node { return Node{Or: nodes} })
+}
+
+// and = constraint { (";" | "and") constraint }
+func (p *rsqlParser) and() (Node, *FieldError) {
+	return p.list(p.constraint, ';', "and", func(nodes []Node) Node { return Node{And: nodes} })
+}
+
+func (p *rsqlParser) list(item func() (Node, *FieldError), separator byte, keyword string, group func([]Node) Node) (Node, *FieldError) {
+	first, err := item()
+	if err != nil {
+		return Node{}, err
+	}
+
+	nodes := []Node{first}
+	for p.separator(separator, keyword) {
+		next, err := item()
+		if err != nil {
+			return Node{}, err
+		}
+		nodes = append(nodes, next)
+	}
+
+	if len(nodes) == 1 {
+		return first, nil
+	}
+
+	return group(nodes), nil
+}
+
+// constraint = "(" or ")" | selector operator argument
+func (p *rsqlParser) constraint() (Node, *FieldError) {
+	p.skipSpaces()
+
+	if p.peek() == '(' {
+		p.depth++
+		if p.depth > p.maxDepth {
+			return Node{}, &FieldError{Code: CodeLimitExceeded, Message: fmt.Sprintf("expression is deeper than %d levels", p.maxDepth)}
+		}
+
+		p.pos++
+		node, err := p.or()
+		if err != nil {
+			return Node{}, err
+		}
+
+		p.skipSpaces()
+		if p.peek() != ')' {
+			return Node{}, p.errorf("expected ')'")
+		}
+		p.pos++
+		p.depth--
+
+		return node, nil
+	}
+
+	selector := p.unquoted()
+	if selector == "" {
+		return Node{}, p.errorf("expected a selector")
+	}
+
+	op, err := p.operator()
+	if err != nil {
+		return Node{}, err
+	}
+
+	value, err := p.argument()
+	if err != nil {
+		return Node{}, err
+	}
+
+	return Node{Field: selector, Op: op, Value: value}, nil
+}
+
+func (p *rsqlParser) operator() (Operator, *FieldError) {
+	p.skipSpaces()
+	start := p.pos
+
+	for _, item := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if strings.HasPrefix(p.input[p.pos:], item) {
+			p.pos += len(item)
+			return rsqlOperators[item], nil
+		}
+	}
+
+	if p.peek() == '=' {
+		end := p.pos + 1
+		for end < len(p.input) && p.input[end] >= 'a' && p.input[end] <= 'z' {
+			end++
+		}
+
+		if end > p.pos+1 && end < len(p.input) && p.input[end] == '=' {
+			name := p.input[p.pos : end+1]
+			p.pos = end + 1
+
+			if op, ok := rsqlOperators[name]; ok {
+				return op, nil
+			}
+			return Operator(name[1 : len(name)-1]), nil
+		}
+	}
+
+	p.pos = start
+	return "", p.errorf("expected a comparison operator")
+}
+
+// argument = value | "(" value { "," value } ")"
+func (p *rsqlParser) argument() (any, *FieldError) {
+	p.skipSpaces()
+
+	if p.peek() != '(' {
+		return p.value()
+	}
+	p.pos++
+
+	values := make([]any, 0)
+	for {
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		p.skipSpaces()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ')':
+			p.pos++
+			return values, nil
+		default:
+			return nil, p.errorf("expected ',' or ')'")
+		}
+	}
+}
+
+func (p *rsqlParser) value() (string, *FieldError) {
+	p.skipSpaces()
+
+	quote := p.peek()
+	if quote != '"' && quote != '\'' {
+		value := p.unquoted()
+		if value == "" {
+			return "", p.errorf("expected a value")
+		}
+		return value, nil
+	}
+
+	p.pos++
+
+	var value strings.Builder
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		p.pos++
+
+		switch {
+		case c == '\\' && p.pos < len(p.input):
+			value.WriteByte(p.input[p.pos])
+			p.pos++
+		case c == quote:
+			return value.String(), nil
+		default:
+			value.WriteByte(c)
+		}
+	}
+
+	return "", p.errorf("unterminated quoted value")
+}
+
+// unquoted lê até o próximo caractere reservado da gramática.
+func (p *rsqlParser) unquoted() string {
+	start := p.pos
+	for p.pos < len(p.input) && !strings.ContainsRune(`"'();,=!~<> `, rune(p.input[p.pos])) {
+		p.pos++
+	}
+
+	return p.input[start:p.pos]
+}
+
+// separator consome o separador informado ou a palavra-chave equivalente (ex: "and", "or").
+func (p *rsqlParser) separator(separator byte, keyword string) bool {
+	p.skipSpaces()
+
+	if p.peek() == separator {
+		p.pos++
+		return true
+	}
+
+	rest := p.input[p.pos:]
+	if len(rest) > len(keyword) && strings.EqualFold(rest[:len(keyword)], keyword) && rest[len(keyword)] == ' ' && p.pos > 0 && p.input[p.pos-1] == ' ' {
+		p.pos += len(keyword)
+		return true
+	}
+
+	return false
+}
+
+func (p *rsqlParser) skipSpaces() {
+	for p.pos < len(p.input) && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+}
+func (p *rsqlParser) peek() byte {
+	if p.pos >= len(p.input) {
+		return 0
+	}
+
+	return p.input[p.pos]
+}
+func (p *rsqlParser) errorf(format string, args ...any) *FieldError {
+	return &FieldError{Code: CodeInvalidSyntax, Message: fmt.Sprintf(format, args...) + fmt.Sprintf(" at position %d", p.pos)}
+}
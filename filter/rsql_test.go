@@ -0,0 +1,118 @@
+package filter
+
+import (
+	"strings"
+	"testing"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	query "github.com/MMortari/go-query-builder"
+)
+
+type rsqlTestCase struct {
+	title  string
+	expr   string
+	result string
+	args   []interface{}
+	errs   Errors
+}
+
+func TestDecodeRSQL(t *testing.T) {
+	data := []rsqlTestCase{
+		{
+			title:  "Test Comparison",
+			expr:   "name==John",
+			result: `SELECT * FROM "users" AS "u" WHERE (u.name = $1)`,
+			args:   []interface{}{"John"},
+		},
+		{
+			title:  "Test And Or Precedence",
+			expr:   "name==John;age=gt=18,status=in=(active,pending)",
+			result: `SELECT * FROM "users" AS "u" WHERE (((u.name = $1 AND u.age > $2) OR u.status IN ($3, $4)))`,
+			args:   []interface{}{"John", int64(18), "active", "pending"},
+		},
+		{
+			title:  "Test Groups",
+			expr:   "(status==active,status==pending);age>=18;age<65",
+			result: `SELECT * FROM "users" AS "u" WHERE (((u.status = $1 OR u.status = $2) AND u.age >= $3 AND u.age < $4))`,
+			args:   []interface{}{"active", "pending", int64(18), int64(65)},
+		},
+		{
+			title:  "Test Keywords And Quotes",
+			expr:   `name=ilike='%jo\'s%' and status=out=("on hold", blocked) or deleted_at=null=true`,
+			result: `SELECT * FROM "users" AS "u" WHERE (((u.name ILIKE $1 AND u.status NOT IN ($2, $3)) OR u.deleted_at IS NULL))`,
+			args:   []interface{}{"%jo's%", "on hold", "blocked"},
+		},
+		{
+			title: "Test Schema Errors",
+			expr:  "password==x,age=like=1",
+			errs: Errors{
+				{Field: "password", Path: "or[0]", Code: CodeUnknownField, Message: "field is not filterable"},
+				{Field: "age", Path: "or[1]", Code: CodeInvalidOperator, Message: `operator "like" is not allowed`},
+			},
+		},
+		{
+			title: "Test Missing Operator",
+			expr:  "name=John",
+			errs:  Errors{{Code: CodeInvalidSyntax, Message: "expected a comparison operator at position 4"}},
+		},
+		{
+			title: "Test Unbalanced Parentheses",
+			expr:  "(name==John;age==1",
+			errs:  Errors{{Code: CodeInvalidSyntax, Message: "expected ')' at position 18"}},
+		},
+		{
+			title: "Test Unterminated Quote",
+			expr:  `name=="John`,
+			errs:  Errors{{Code: CodeInvalidSyntax, Message: "unterminated quoted value at position 11"}},
+		},
+		{
+			title: "Test Trailing Data",
+			expr:  "name==John)",
+			errs:  Errors{{Code: CodeInvalidSyntax, Message: "unexpected ')' at position 10"}},
+		},
+		{
+			title: "Test Max Depth",
+			expr:  strings.Repeat("(", 10) + "name==John" + strings.Repeat(")", 10),
+			errs:  Errors{{Code: CodeLimitExceeded, Message: "expression is deeper than 5 levels"}},
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.title, func(t *testing.T) {
+			qb := query.NewQueryBuilder().From("users", "u")
+			qb, err := schema.ParseRSQL(item.expr, qb)
+
+			if item.errs != nil {
+				var errs Errors
+				require.ErrorAs(t, err, &errs)
+				assert.Equal(t, item.errs, errs)
+				return
+			}
+			require.NoError(t, err)
+
+			sql, args := qb.ToSelectSql()
+			assert.Equal(t, item.result, sql)
+			assert.Equal(t, item.args, args)
+
+			_, err = pg_query.Parse(sql)
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("Test Immutable", func(t *testing.T) {
+		base := query.NewQueryBuilder(query.Immutable()).From("users", "u")
+
+		qb, err := schema.ParseRSQL("status==active", base)
+		require.NoError(t, err)
+
+		sql, args := qb.ToSelectSql()
+		assert.Equal(t, `SELECT * FROM "users" AS "u" WHERE (u.status = $1)`, sql)
+		assert.Equal(t, []interface{}{"active"}, args)
+
+		sql, _ = base.ToSelectSql()
+		assert.Equal(t, `SELECT * FROM "users" AS "u"`, sql)
+	})
+}
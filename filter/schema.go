@@ -0,0 +1,373 @@
+package filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	query "github.com/MMortari/go-query-builder"
+)
+
+// Type é o tipo de valor aceito por um campo filtrável.
+type Type int
+
+const (
+	String Type = iota
+	Int
+	Float
+	Bool
+	Time
+)
+
+func (t Type) String() string {
+	switch t {
+	case Int:
+		return "int"
+	case Float:
+		return "float"
+	case Bool:
+		return "bool"
+	case Time:
+		return "time"
+	default:
+		return "string"
+	}
+}
+
+// Operator é o nome público de um operador de filtro, ex: `age[gte]=18`.
+type Operator string
+
+const (
+	Eq    Operator = "eq"
+	Ne    Operator = "ne"
+	Gt    Operator = "gt"
+	Gte   Operator = "gte"
+	Lt    Operator = "lt"
+	Lte   Operator = "lte"
+	Like  Operator = "like"
+	ILike Operator = "ilike"
+	In    Operator = "in"
+	NotIn Operator = "nin"
+	// IsNull recebe um valor booleano: true gera `IS NULL` e false gera `IS NOT NULL`.
+	IsNull Operator = "null"
+
+	// Contains, StartsWith e EndsWith geram `LIKE` com o valor escapado, ex: contains `50%` gera `LIKE '%50\%%'`.
+	// Só podem ser utilizados em campos String.
+	Contains   Operator = "contains"
+	StartsWith Operator = "startswith"
+	EndsWith   Operator = "endswith"
+)
+
+var operatorSQL = map[Operator]string{
+	Eq:    "=",
+	Ne:    "!=",
+	Gt:    ">",
+	Gte:   ">=",
+	Lt:    "<",
+	Lte:   "<=",
+	Like:  "LIKE",
+	ILike: "ILIKE",
+	In:    "IN",
+	NotIn: "NOT IN",
+}
+
+// Field descreve um campo exposto para filtro e ordenação.
+type Field struct {
+	// Column é a coluna utilizada no SQL. Quando vazio, o nome do campo é utilizado.
+	Column string
+	Type   Type
+	// Operators são os operadores permitidos. Quando vazio, somente Eq é permitido.
+	Operators []Operator
+	Sortable  bool
+}
+
+// Schema é a lista de campos que podem ser filtrados e ordenados, indexada pelo nome público do campo.
+//
+// Campos fora do Schema são rejeitados, evitando que o cliente filtre ou ordene por colunas arbitrárias.
+//
+// Exemplo de uso:
+//
+//	schema := filter.Schema{
+//	    Fields: map[string]filter.Field{
+//	        "status":     {Column: "u.status", Operators: []filter.Operator{filter.Eq, filter.In}},
+//	        "age":        {Column: "u.age", Type: filter.Int, Operators: []filter.Operator{filter.Eq, filter.Gte, filter.Lte}},
+//	        "created_at": {Column: "u.created_at", Type: filter.Time, Sortable: true},
+//	    },
+//	    DefaultPageSize: 20,
+//	    MaxPageSize:     100,
+//	}
+type Schema struct {
+	Fields map[string]Field
+	// DefaultPageSize é utilizado quando page_size não é informado. Quando zero, a paginação só é aplicada se
+	// page ou page_size forem informados.
+	DefaultPageSize int
+	// MaxPageSize limita o page_size aceito. Quando zero, não há limite.
+	MaxPageSize int
+
+	// MaxDepth, MaxConditions e MaxBytes limitam as expressões aceitas por DecodeJSON (profundidade de grupos
+	// aninhados, número total de condições e tamanho do documento). Quando zero, os valores Default* são utilizados.
+	MaxDepth      int
+	MaxConditions int
+	MaxBytes      int
+}
+
+// Limites padrão das expressões de filtro.
+const (
+	DefaultMaxDepth      = 5
+	DefaultMaxConditions = 50
+	DefaultMaxBytes      = 64 << 10
+)
+
+// Códigos de FieldError.
+const (
+	CodeUnknownField    = "unknown_field"
+	CodeInvalidOperator = "invalid_operator"
+	CodeInvalidValue    = "invalid_value"
+	CodeNotSortable     = "not_sortable"
+	CodeInvalidPage     = "invalid_page"
+	CodeInvalidSyntax   = "invalid_syntax"
+	CodeLimitExceeded   = "limit_exceeded"
+)
+
+// FieldError descreve um parâmetro de filtro rejeitado.
+type FieldError struct {
+	Field string `json:"field,omitempty"`
+	// Path indica a posição do erro em expressões aninhadas, ex: `and[1].or[0]`.
+	Path    string `json:"path,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e FieldError) Error() string {
+	switch {
+	case e.Path != "" && e.Field != "":
+		return fmt.Sprintf("%s (%s): %s", e.Field, e.Path, e.Message)
+	case e.Path != "":
+		return fmt.Sprintf("%s: %s", e.Path, e.Message)
+	default:
+		return fmt.Sprintf("%s: %s", e.Field, e.Message)
+	}
+}
+
+// Errors é a lista de erros de validação retornada pelos parsers deste pacote.
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, item := range e {
+		messages = append(messages, item.Error())
+	}
+
+	return "filter: " + strings.Join(messages, "; ")
+}
+
+// condition valida o campo e o operador no Schema e converte os valores para o tipo do campo.
+func (s Schema) condition(name string, op Operator, values []any) (query.Where, *FieldError) {
+	field, ok := s.Fields[name]
+	if !ok {
+		return query.Where{}, &FieldError{Field: name, Code: CodeUnknownField, Message: "field is not filterable"}
+	}
+	if !field.allows(op) {
+		return query.Where{}, &FieldError{Field: name, Code: CodeInvalidOperator, Message: fmt.Sprintf("operator %q is not allowed", op)}
+	}
+
+	column := field.column(name)
+
+	if op == IsNull {
+		if len(values) != 1 {
+			return query.Where{}, &FieldError{Field: name, Code: CodeInvalidValue, Message: "expected a single boolean value"}
+		}
+
+		isNull, err := coerce(Bool, values[0])
+		if err != nil {
+			return query.Where{}, &FieldError{Field: name, Code: CodeInvalidValue, Message: err.Error()}
+		}
+		if isNull.(bool) {
+			return query.Where{Column: column, Type: "IS NULL"}, nil
+		}
+		return query.Where{Column: column, Type: "IS NOT NULL"}, nil
+	}
+
+	converted := make([]any, 0, len(values))
+	for _, item := range values {
+		value, err := coerce(field.Type, item)
+		if err != nil {
+			return query.Where{}, &FieldError{Field: name, Code: CodeInvalidValue, Message: err.Error()}
+		}
+		converted = append(converted, value)
+	}
+
+	if op == Contains || op == StartsWith || op == EndsWith {
+		if field.Type != String {
+			return query.Where{}, &FieldError{Field: name, Code: CodeInvalidOperator, Message: fmt.Sprintf("operator %q requires a string field", op)}
+		}
+		if len(converted) != 1 {
+			return query.Where{}, &FieldError{Field: name, Code: CodeInvalidValue, Message: "expected a single value"}
+		}
+		return query.Where{Column: column, Type: "LIKE", Val: likePattern(op, converted[0].(string))}, nil
+	}
+
+	if op == In || op == NotIn {
+		if len(converted) == 0 {
+			return query.Where{}, &FieldError{Field: name, Code: CodeInvalidValue, Message: "expected at least one value"}
+		}
+		return query.Where{Column: column, Type: operatorSQL[op], Val: converted}, nil
+	}
+
+	if len(converted) != 1 {
+		return query.Where{}, &FieldError{Field: name, Code: CodeInvalidValue, Message: "expected a single value"}
+	}
+
+	return query.Where{Column: column, Type: operatorSQL[op], Val: converted[0]}, nil
+}
+
+// orderBy valida se o campo pode ser ordenado e retorna a ordenação correspondente.
+func (s Schema) orderBy(name string, desc bool) (query.OrderBy, *FieldError) {
+	field, ok := s.Fields[name]
+	if !ok {
+		return query.OrderBy{}, &FieldError{Field: name, Code: CodeUnknownField, Message: "field is not sortable"}
+	}
+	if !field.Sortable {
+		return query.OrderBy{}, &FieldError{Field: name, Code: CodeNotSortable, Message: "field is not sortable"}
+	}
+
+	if desc {
+		return query.OrderBy{Column: field.column(name), Type: "DESC"}, nil
+	}
+
+	return query.OrderBy{Column: field.column(name), Type: "ASC"}, nil
+}
+
+// likePattern escapa os curingas de value e adiciona `%` conforme o operador.
+func likePattern(op Operator, value string) string {
+	value = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+
+	switch op {
+	case StartsWith:
+		return value + "%"
+	case EndsWith:
+		return "%" + value
+	default:
+		return "%" + value + "%"
+	}
+}
+
+func (s Schema) maxDepth() int {
+	if s.MaxDepth == 0 {
+		return DefaultMaxDepth
+	}
+	return s.MaxDepth
+}
+func (s Schema) maxConditions() int {
+	if s.MaxConditions == 0 {
+		return DefaultMaxConditions
+	}
+	return s.MaxConditions
+}
+func (s Schema) maxBytes() int {
+	if s.MaxBytes == 0 {
+		return DefaultMaxBytes
+	}
+	return s.MaxBytes
+}
+
+func (f Field) column(name string) string {
+	if f.Column == "" {
+		return name
+	}
+
+	return f.Column
+}
+func (f Field) allows(op Operator) bool {
+	if len(f.Operators) == 0 {
+		return op == Eq
+	}
+
+	for _, item := range f.Operators {
+		if item == op {
+			return true
+		}
+	}
+
+	return false
+}
+
+// coerce converte value para o tipo t. Strings são interpretadas (ex: valores de query string), os demais
+// valores precisam ser compatíveis com o tipo.
+func coerce(t Type, value any) (any, error) {
+	switch raw := value.(type) {
+	case string:
+		return parse(t, raw)
+	case json.Number:
+		if t == String {
+			return nil, fmt.Errorf("expected a string value, got %s", raw)
+		}
+		return parse(t, raw.String())
+	}
+
+	switch t {
+	case Int:
+		switch v := value.(type) {
+		case int:
+			return int64(v), nil
+		case int64:
+			return v, nil
+		case float64:
+			if v == float64(int64(v)) {
+				return int64(v), nil
+			}
+		}
+	case Float:
+		switch v := value.(type) {
+		case int:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		case float64:
+			return v, nil
+		}
+	case Bool:
+		if v, ok := value.(bool); ok {
+			return v, nil
+		}
+	case Time:
+		if v, ok := value.(time.Time); ok {
+			return v, nil
+		}
+	}
+
+	return nil, fmt.Errorf("expected a %s value, got %v", t, value)
+}
+func parse(t Type, raw string) (any, error) {
+	switch t {
+	case Int:
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected an int value, got %q", raw)
+		}
+		return value, nil
+	case Float:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected a float value, got %q", raw)
+		}
+		return value, nil
+	case Bool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("expected a bool value, got %q", raw)
+		}
+		return value, nil
+	case Time:
+		for _, layout := range []string{time.RFC3339Nano, time.DateOnly} {
+			if value, err := time.Parse(layout, raw); err == nil {
+				return value, nil
+			}
+		}
+		return nil, fmt.Errorf("expected an RFC 3339 time or date value, got %q", raw)
+	default:
+		return raw, nil
+	}
+}
@@ -0,0 +1,166 @@
+package filter
+
+import (
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	query "github.com/MMortari/go-query-builder"
+)
+
+// Parâmetros de query string reservados por ParseURL.
+const (
+	ParamSort     = "sort"
+	ParamPage     = "page"
+	ParamPageSize = "page_size"
+)
+
+// ParseURL interpreta os parâmetros de uma query string e retorna qb com eles aplicados.
+//
+// Formato aceito:
+//
+//	status=active          filtro com o operador eq
+//	age[gte]=18            filtro com o operador informado entre colchetes
+//	status[in]=a,b         valores separados por vírgula para in e nin
+//	deleted_at[null]=true  IS NULL / IS NOT NULL
+//	sort=-created_at,name  ordenação, o prefixo "-" indica DESC
+//	page=2&page_size=20    paginação via PaginationPaged
+//
+// Todos os filtros são adicionados em um único WhereAnd. O builder retornado deve ser utilizado no lugar de qb, já
+// que no modo query.Immutable os métodos de qb retornam uma cópia. Quando algum parâmetro é inválido, qb é retornado
+// sem alterações e o erro é do tipo Errors, com um FieldError por parâmetro rejeitado.
+//
+// Exemplo de uso:
+//
+//	qb, err := schema.ParseURL(r.URL.Query(), query.NewQueryBuilder().From("users", "u"))
+//	if err != nil {
+//	    var errs filter.Errors
+//	    errors.As(err, &errs) // 400 Bad Request
+//	}
+func (s Schema) ParseURL(values url.Values, qb *query.QueryBuilder) (*query.QueryBuilder, error) {
+	var (
+		errs     Errors
+		wheres   []query.Where
+		orderBys []query.OrderBy
+	)
+
+	// As chaves são ordenadas para que a numeração dos parâmetros seja sempre a mesma
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		if key == ParamSort || key == ParamPage || key == ParamPageSize {
+			continue
+		}
+
+		name, op, ok := parseKey(key)
+		if !ok {
+			errs = append(errs, FieldError{Field: key, Code: CodeInvalidOperator, Message: "malformed filter parameter"})
+			continue
+		}
+
+		for _, raw := range values[key] {
+			where, err := s.condition(name, op, splitValues(op, raw))
+			if err != nil {
+				errs = append(errs, *err)
+				continue
+			}
+			wheres = append(wheres, where)
+		}
+	}
+
+	for _, raw := range values[ParamSort] {
+		for _, item := range strings.Split(raw, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+
+			orderBy, err := s.orderBy(strings.TrimPrefix(item, "-"), strings.HasPrefix(item, "-"))
+			if err != nil {
+				errs = append(errs, *err)
+				continue
+			}
+			orderBys = append(orderBys, orderBy)
+		}
+	}
+
+	page, pageSize, pageErrs := s.pagination(values)
+	errs = append(errs, pageErrs...)
+
+	if len(errs) != 0 {
+		return qb, errs
+	}
+
+	if len(wheres) != 0 {
+		qb = qb.WhereAnd(wheres...)
+	}
+	for _, item := range orderBys {
+		qb = qb.OrderBy(item)
+	}
+	if pageSize != 0 {
+		qb = qb.PaginationPaged(page, pageSize)
+	}
+
+	return qb, nil
+}
+
+func (s Schema) pagination(values url.Values) (page int, pageSize int, errs Errors) {
+	page, pageSize = 1, s.DefaultPageSize
+
+	if raw := values.Get(ParamPage); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 1 {
+			errs = append(errs, FieldError{Field: ParamPage, Code: CodeInvalidPage, Message: "must be a positive integer"})
+		}
+		page = value
+	}
+
+	if raw := values.Get(ParamPageSize); raw != "" {
+		value, err := strconv.Atoi(raw)
+		switch {
+		case err != nil || value < 1:
+			errs = append(errs, FieldError{Field: ParamPageSize, Code: CodeInvalidPage, Message: "must be a positive integer"})
+		case s.MaxPageSize != 0 && value > s.MaxPageSize:
+			errs = append(errs, FieldError{Field: ParamPageSize, Code: CodeInvalidPage, Message: "must be at most " + strconv.Itoa(s.MaxPageSize)})
+		}
+		pageSize = value
+	}
+
+	if pageSize == 0 && values.Has(ParamPage) {
+		errs = append(errs, FieldError{Field: ParamPageSize, Code: CodeInvalidPage, Message: "is required when page is informed"})
+	}
+
+	return page, pageSize, errs
+}
+
+// parseKey separa `campo[operador]` em nome e operador. Sem colchetes, o operador é Eq.
+func parseKey(key string) (name string, op Operator, ok bool) {
+	open := strings.IndexByte(key, '[')
+	if open == -1 {
+		return key, Eq, key != ""
+	}
+	if open == 0 || !strings.HasSuffix(key, "]") {
+		return "", "", false
+	}
+
+	return key[:open], Operator(key[open+1 : len(key)-1]), true
+}
+func splitValues(op Operator, raw string) []any {
+	if op != In && op != NotIn {
+		return []any{raw}
+	}
+
+	values := make([]any, 0)
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,127 @@
+package filter
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	query "github.com/MMortari/go-query-builder"
+)
+
+var schema = Schema{
+	Fields: map[string]Field{
+		"status":     {Column: "u.status", Operators: []Operator{Eq, Ne, In, NotIn}, Sortable: true},
+		"name":       {Column: "u.name", Operators: []Operator{Eq, ILike}, Sortable: true},
+		"age":        {Column: "u.age", Type: Int, Operators: []Operator{Eq, Gt, Gte, Lt, Lte}},
+		"salary":     {Type: Float, Operators: []Operator{Gte}},
+		"active":     {Column: "u.active", Type: Bool},
+		"created_at": {Column: "u.created_at", Type: Time, Operators: []Operator{Gte, Lt}, Sortable: true},
+		"deleted_at": {Column: "u.deleted_at", Type: Time, Operators: []Operator{IsNull}},
+	},
+	DefaultPageSize: 10,
+	MaxPageSize:     50,
+}
+
+type urlTestCase struct {
+	title  string
+	query  string
+	result string
+	args   []interface{}
+	errs   Errors
+}
+
+func TestParseURL(t *testing.T) {
+	data := []urlTestCase{
+		{
+			title:  "Test Empty",
+			query:  "",
+			result: `SELECT * FROM "users" AS "u" LIMIT 10 OFFSET 0`,
+			args:   []interface{}{},
+		},
+		{
+			title:  "Test Filters",
+			query:  "status=active&age[gte]=18&age[lt]=65&salary[gte]=1500.5&active=true",
+			result: `SELECT * FROM "users" AS "u" WHERE (u.active = $1 AND u.age >= $2 AND u.age < $3 AND salary >= $4 AND u.status = $5) LIMIT 10 OFFSET 0`,
+			args:   []interface{}{true, int64(18), int64(65), 1500.5, "active"},
+		},
+		{
+			title:  "Test In And Null",
+			query:  "status[in]=active,pending&deleted_at[null]=true",
+			result: `SELECT * FROM "users" AS "u" WHERE (u.deleted_at IS NULL AND u.status IN ($1, $2)) LIMIT 10 OFFSET 0`,
+			args:   []interface{}{"active", "pending"},
+		},
+		{
+			title:  "Test Not Null And Time",
+			query:  "deleted_at[null]=false&created_at[gte]=2025-01-31",
+			result: `SELECT * FROM "users" AS "u" WHERE (u.created_at >= $1 AND u.deleted_at IS NOT NULL) LIMIT 10 OFFSET 0`,
+			args:   []interface{}{time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			title:  "Test Sort And Page",
+			query:  "name[ilike]=%25mark%25&sort=-created_at,name&page=3&page_size=20",
+			result: `SELECT * FROM "users" AS "u" WHERE (u.name ILIKE $1) ORDER BY u.created_at DESC, u.name ASC LIMIT 20 OFFSET 40`,
+			args:   []interface{}{"%mark%"},
+		},
+		{
+			title: "Test Errors",
+			query: "email=a@b.com&age[like]=1&age=abc&sort=age,-password&page=0&page_size=100&status[x&status[in]=",
+			errs: Errors{
+				{Field: "age", Code: CodeInvalidValue, Message: `expected an int value, got "abc"`},
+				{Field: "age", Code: CodeInvalidOperator, Message: `operator "like" is not allowed`},
+				{Field: "email", Code: CodeUnknownField, Message: "field is not filterable"},
+				{Field: "status", Code: CodeInvalidValue, Message: "expected at least one value"},
+				{Field: "status[x", Code: CodeInvalidOperator, Message: "malformed filter parameter"},
+				{Field: "age", Code: CodeNotSortable, Message: "field is not sortable"},
+				{Field: "password", Code: CodeUnknownField, Message: "field is not sortable"},
+				{Field: "page", Code: CodeInvalidPage, Message: "must be a positive integer"},
+				{Field: "page_size", Code: CodeInvalidPage, Message: "must be at most 50"},
+			},
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.title, func(t *testing.T) {
+			values, err := url.ParseQuery(item.query)
+			require.NoError(t, err)
+
+			qb := query.NewQueryBuilder().From("users", "u")
+			qb, err = schema.ParseURL(values, qb)
+
+			if item.errs != nil {
+				var errs Errors
+				require.ErrorAs(t, err, &errs)
+				assert.Equal(t, item.errs, errs)
+
+				sql, _ := qb.ToSelectSql()
+				assert.Equal(t, `SELECT * FROM "users" AS "u"`, sql, "builder should not be changed")
+				return
+			}
+			require.NoError(t, err)
+
+			sql, args := qb.ToSelectSql()
+			assert.Equal(t, item.result, sql)
+			assert.Equal(t, item.args, args)
+
+			_, err = pg_query.Parse(sql)
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("Test Immutable", func(t *testing.T) {
+		base := query.NewQueryBuilder(query.Immutable()).From("users", "u")
+
+		qb, err := schema.ParseURL(url.Values{"status": {"active"}, "sort": {"-name"}, "page": {"2"}}, base)
+		require.NoError(t, err)
+
+		sql, args := qb.ToSelectSql()
+		assert.Equal(t, `SELECT * FROM "users" AS "u" WHERE (u.status = $1) ORDER BY u.name DESC LIMIT 10 OFFSET 10`, sql)
+		assert.Equal(t, []interface{}{"active"}, args)
+
+		sql, _ = base.ToSelectSql()
+		assert.Equal(t, `SELECT * FROM "users" AS "u"`, sql)
+	})
+}
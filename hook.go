@@ -0,0 +1,117 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// EventKind indica a etapa da query descrita por um Event.
+type EventKind string
+
+const (
+	// EventBuild é emitido a cada renderização (ToSelectSql, ToSelectTotalSql, ToSelectWithTotalSql, ToUpdateQuery e
+	// ToDeleteQuery).
+	EventBuild EventKind = "build"
+	// EventExecute é emitido pelo pacote executor a cada query executada no banco.
+	EventExecute EventKind = "execute"
+)
+
+// Event descreve uma renderização ou execução de query recebida pelo Hook.
+type Event struct {
+	Kind EventKind
+	// Operation e Table possuem os mesmos valores dos atributos db.operation.name e db.collection.name.
+	Operation string
+	Table     string
+	// SQL é a query com os valores inseridos diretamente no SQL já redigidos (veja Statement.Text).
+	SQL string
+	// Args são os parâmetros da query após a política de redação configurada com Redact.
+	Args []any
+	// Columns é a coluna de cada parâmetro de Args (veja Statement.Columns).
+	Columns  []string
+	Duration time.Duration
+	Err      error
+}
+
+// Hook recebe os eventos de renderização e execução das queries, ex: para logs estruturados.
+type Hook interface {
+	OnQuery(ctx context.Context, event Event)
+}
+
+// HookFunc permite utilizar uma função como Hook.
+type HookFunc func(ctx context.Context, event Event)
+
+func (f HookFunc) OnQuery(ctx context.Context, event Event) {
+	f(ctx, event)
+}
+
+// SetHook registra um Hook chamado a cada renderização da query e a cada execução feita pelo pacote executor.
+//
+// Exemplo de uso:
+//
+//	qb := query.NewQueryBuilder(
+//	    query.SetOtelSpan(span),
+//	    query.SetHook(query.SlogHook(logger, query.SlogSlowQuery(200*time.Millisecond, slog.LevelWarn))),
+//	)
+func SetHook(hook Hook) QueryBuilderConfig {
+	return func(q *QueryBuilder) {
+		q.hook = hook
+	}
+}
+
+// Emit envia event ao Hook configurado com SetHook, aplicando a política de redação em Args com as colunas de
+// event.Columns. Table é preenchido com a tabela do builder quando vazio.
+//
+// É utilizado pelo pacote executor para notificar a execução das queries e não faz nada quando não há Hook.
+func (q *QueryBuilder) Emit(ctx context.Context, event Event) {
+	if q.hook == nil {
+		return
+	}
+
+	if event.Table == "" {
+		event.Table = q.table
+	}
+	event.Args = q.redactArgs(event.Args, event.Columns)
+
+	q.hook.OnQuery(ctx, event)
+}
+
+func (q *QueryBuilder) emitBuild(start time.Time, stmt Statement, err error) {
+	q.Emit(context.Background(), Event{
+		Kind:      EventBuild,
+		Operation: stmt.Operation,
+		SQL:       stmt.Text,
+		Args:      stmt.Args,
+		Columns:   stmt.Columns,
+		Duration:  time.Since(start),
+		Err:       err,
+	})
+}
+
+// redactArgs aplica a política de redação nos parâmetros, mantendo o valor original das colunas permitidas. Como os
+// parâmetros são posicionais, valores de colunas em Deny são substituídos por RedactedValue.
+func (q *QueryBuilder) redactArgs(args []any, columns []string) []any {
+	if q.redaction == nil || len(args) == 0 {
+		return args
+	}
+
+	redacted := make([]any, len(args))
+	for i, item := range args {
+		var column string
+		if i < len(columns) {
+			column = columns[i]
+		}
+
+		value, ok := q.redaction.redact(column, fmt.Sprint(item))
+		switch {
+		case !ok:
+			redacted[i] = RedactedValue
+		case matchColumn(q.redaction.Allow, column):
+			redacted[i] = item
+		default:
+			redacted[i] = value
+		}
+	}
+
+	return redacted
+}
@@ -0,0 +1,134 @@
+package query
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHook(t *testing.T) {
+	t.Run("Validate Build Event", func(t *testing.T) {
+		var events []Event
+		hook := HookFunc(func(ctx context.Context, event Event) {
+			events = append(events, event)
+		})
+
+		qb := NewQueryBuilder(SetHook(hook), Redact(RedactionPolicy{Allow: []string{"id"}, Deny: []string{"password"}, Redactor: Mask(1)})).
+			From("users", "u").
+			Values(Value{Column: "password", Val: "secret"}, Value{Column: "name", Val: "Mark"}).
+			WhereAnd(Where{Column: "u.id", Type: "in", Val: []int{1, 2}})
+
+		stmt, err := qb.BuildUpdate()
+		require.NoError(t, err)
+
+		require.Len(t, events, 1)
+		assert.Equal(t, EventBuild, events[0].Kind)
+		assert.Equal(t, "UPDATE", events[0].Operation)
+		assert.Equal(t, "users", events[0].Table)
+		assert.Equal(t, stmt.SQL, events[0].SQL)
+		assert.Equal(t, []any{RedactedValue, "***k", 1, 2}, events[0].Args)
+		assert.Equal(t, []any{"secret", "Mark", 1, 2}, stmt.Args)
+		assert.NoError(t, events[0].Err)
+
+		qb.Emit(context.Background(), Event{Kind: EventExecute, Operation: "UPDATE", SQL: stmt.SQL, Args: stmt.Args, Columns: stmt.Columns, Duration: time.Second})
+
+		require.Len(t, events, 2)
+		assert.Equal(t, EventExecute, events[1].Kind)
+		assert.Equal(t, "users", events[1].Table)
+		assert.Equal(t, []any{RedactedValue, "***k", 1, 2}, events[1].Args)
+	})
+
+	t.Run("Validate Build Error", func(t *testing.T) {
+		var events []Event
+		qb := NewQueryBuilder(EmptyIn(EmptyInError), SetHook(HookFunc(func(ctx context.Context, event Event) {
+			events = append(events, event)
+		}))).From("users").WhereAnd(Where{Column: "id", Type: "in", Val: []int{}})
+
+		qb.ToSelectTotalSql()
+
+		require.Len(t, events, 1)
+		assert.Equal(t, "SELECT", events[0].Operation)
+		assert.ErrorIs(t, events[0].Err, ErrEmptyIn)
+	})
+
+	t.Run("Validate Emit Without Hook", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			NewQueryBuilder().From("users").Emit(context.Background(), Event{Kind: EventExecute})
+		})
+	})
+}
+
+func TestSlogHook(t *testing.T) {
+	logs := func(hook func(logger *slog.Logger) Hook, events ...Event) []map[string]any {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+		h := hook(logger)
+		for _, item := range events {
+			h.OnQuery(context.Background(), item)
+		}
+
+		var result []map[string]any
+		decoder := json.NewDecoder(&buf)
+		for decoder.More() {
+			var item map[string]any
+			require.NoError(t, decoder.Decode(&item))
+			result = append(result, item)
+		}
+		return result
+	}
+
+	execute := Event{Kind: EventExecute, Operation: "SELECT", Table: "users", SQL: `SELECT * FROM "users" WHERE (id = $1)`, Args: []any{1}, Duration: 10 * time.Millisecond}
+	build := Event{Kind: EventBuild, Operation: "SELECT", Table: "users", SQL: execute.SQL, Args: []any{1}}
+
+	t.Run("Validate Levels", func(t *testing.T) {
+		slow := execute
+		slow.Duration = time.Second
+		failed := execute
+		failed.Err = errors.New("connection reset")
+
+		result := logs(func(logger *slog.Logger) Hook {
+			return SlogHook(logger, SlogSlowQuery(500*time.Millisecond, slog.LevelWarn))
+		}, execute, build, slow, failed)
+
+		require.Len(t, result, 3)
+
+		assert.Equal(t, "DEBUG", result[0]["level"])
+		assert.Equal(t, "query", result[0]["msg"])
+		assert.Equal(t, "execute", result[0]["kind"])
+		assert.Equal(t, "SELECT", result[0]["operation"])
+		assert.Equal(t, "users", result[0]["table"])
+		assert.Equal(t, execute.SQL, result[0]["sql"])
+		assert.Equal(t, []any{float64(1)}, result[0]["args"])
+		assert.Equal(t, float64(10*time.Millisecond), result[0]["duration"])
+		assert.NotContains(t, result[0], "slow")
+
+		assert.Equal(t, "WARN", result[1]["level"])
+		assert.Equal(t, true, result[1]["slow"])
+
+		assert.Equal(t, "ERROR", result[2]["level"])
+		assert.Equal(t, "connection reset", result[2]["error"])
+	})
+
+	t.Run("Validate Thresholds", func(t *testing.T) {
+		result := logs(func(logger *slog.Logger) Hook {
+			return SlogHook(slog.New(slog.DiscardHandler))
+		}, execute)
+		assert.Empty(t, result)
+
+		result = logs(func(logger *slog.Logger) Hook {
+			return SlogHook(slog.New(logger.Handler().WithGroup("db")), SlogLevel(slog.LevelInfo), SlogErrorLevel(slog.LevelWarn), SlogBuilds())
+		}, execute, build)
+
+		require.Len(t, result, 2)
+		assert.Equal(t, "INFO", result[0]["level"])
+		assert.Equal(t, "build", result[1]["db"].(map[string]any)["kind"])
+	})
+}
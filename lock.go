@@ -0,0 +1,46 @@
+package query
+
+// OptimisticLock ativa o controle de concorrência otimista pela coluna de versão informada (ex: version).
+//
+// Com a opção ativa, ToUpdateQuery adiciona `<column> = <column> + 1` aos valores. Quando a versão lida pelo
+// cliente é informada com ExpectVersion, ToUpdateQuery e ToDeleteQuery também adicionam `<alias>.<column> = $n`
+// às condições, e os executores retornam ErrStaleVersion quando nenhum registro é alterado.
+//
+// Exemplo de uso:
+//
+//	qb := query.NewQueryBuilder(query.OptimisticLock("version")).
+//	    From("users").
+//	    Values(query.Value{Column: "name", Val: "Mark"}).
+//	    WhereAnd(query.Where{Column: "id", Type: "=", Val: 1}).
+//	    ExpectVersion(3)
+//
+//	sql, params := qb.ToUpdateQuery()
+//	// SQL: UPDATE "users" SET name = $1, version = version + 1 WHERE "users".version = $2 AND ((id = $3))
+//
+//	_, err := exec.Exec(ctx, qb)
+//	if errors.Is(err, query.ErrStaleVersion) {
+//	    // o registro foi alterado por outra requisição
+//	}
+func OptimisticLock(column string) QueryBuilderConfig {
+	return func(q *QueryBuilder) {
+		q.config.lockColumn = column
+	}
+}
+
+// ExpectVersion informa a versão do registro lida pelo cliente, verificada no UPDATE / DELETE configurado com
+// OptimisticLock.
+func (q *QueryBuilder) ExpectVersion(version any) *QueryBuilder {
+	q = q.builder()
+	q.version = &version
+	return q
+}
+
+// ExpectedVersion retorna a versão informada com ExpectVersion. Retorna false quando o builder não foi configurado
+// com OptimisticLock ou quando a versão não foi informada.
+func (q *QueryBuilder) ExpectedVersion() (version any, ok bool) {
+	if q.config.lockColumn == "" || q.version == nil {
+		return nil, false
+	}
+
+	return *q.version, true
+}
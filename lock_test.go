@@ -0,0 +1,87 @@
+package query
+
+import (
+	"testing"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptimisticLock(t *testing.T) {
+	lock := OptimisticLock("version")
+
+	data := []struct {
+		title  string
+		render func() (string, []interface{})
+		result string
+		args   []interface{}
+	}{
+		{
+			title: "Test Update",
+			render: NewQueryBuilder(lock).From("users").
+				Values(Value{Column: "name", Val: "Mark"}).
+				WhereAnd(Where{Column: "id", Type: "=", Val: 1}).
+				ExpectVersion(3).
+				ToUpdateQuery,
+			result: `UPDATE "users" SET name = $1, version = version + 1 WHERE "users".version = $2 AND ((id = $3))`,
+			args:   []interface{}{"Mark", 3, 1},
+		},
+		{
+			title:  "Test Update Without Expected Version",
+			render: NewQueryBuilder(lock).From("users").Values(Value{Column: "name", Val: "Mark"}).ToUpdateQuery,
+			result: `UPDATE "users" SET name = $1, version = version + 1`,
+			args:   []interface{}{"Mark"},
+		},
+		{
+			title: "Test Update Tenant",
+			render: NewQueryBuilder(lock, Tenant("tenant_id", 7)).From("users", "u").
+				Values(Value{Column: "name", Val: "Mark"}).
+				ExpectVersion(3).
+				ToUpdateQuery,
+			result: `UPDATE "users" AS "u" SET name = $1, version = version + 1 WHERE "u".tenant_id = $2 AND "u".version = $3`,
+			args:   []interface{}{"Mark", 7, 3},
+		},
+		{
+			title:  "Test Delete",
+			render: NewQueryBuilder(lock).From("users").WhereAnd(Where{Column: "id", Type: "=", Val: 1}).ExpectVersion(3).ToDeleteQuery,
+			result: `DELETE FROM "users" WHERE "users".version = $1 AND ((id = $2))`,
+			args:   []interface{}{3, 1},
+		},
+		{
+			title:  "Test Select Ignores Version",
+			render: NewQueryBuilder(lock).From("users").ExpectVersion(3).ToSelectSql,
+			result: `SELECT * FROM "users"`,
+			args:   []interface{}{},
+		},
+		{
+			title:  "Test Expected Version Without Lock",
+			render: NewQueryBuilder().From("users").Values(Value{Column: "name", Val: "Mark"}).ExpectVersion(3).ToUpdateQuery,
+			result: `UPDATE "users" SET name = $1`,
+			args:   []interface{}{"Mark"},
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.title, func(t *testing.T) {
+			query, args := item.render()
+
+			assert.Equal(t, item.result, query)
+			assert.Equal(t, item.args, args)
+
+			_, err := pg_query.Parse(query)
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("Validate Expected Version", func(t *testing.T) {
+		version, ok := NewQueryBuilder(lock).ExpectVersion(3).ExpectedVersion()
+		assert.True(t, ok)
+		assert.Equal(t, 3, version)
+
+		_, ok = NewQueryBuilder(lock).ExpectedVersion()
+		assert.False(t, ok)
+
+		_, ok = NewQueryBuilder().ExpectVersion(3).ExpectedVersion()
+		assert.False(t, ok)
+	})
+}
@@ -1,9 +1,13 @@
 package query
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -13,6 +17,15 @@ type Where struct {
 	Column string
 	Type   string
 	Val    interface{}
+
+	// And e Or agrupam condições aninhadas, renderizadas entre parênteses no lugar de Column/Type/Val.
+	// Ex: Where{Or: []Where{{Column: "a", Type: "=", Val: 1}, {Column: "b", Type: "=", Val: 2}}} gera `(a = $1 OR b = $2)`.
+	And []Where
+	Or  []Where
+	// Not nega a condição ou o grupo, ex: `NOT (a = $1)`.
+	Not bool
+	// ColumnExpr substitui Column por uma expressão com parâmetros, ex: `query.Raw("coalesce(nickname, ?)", "")`.
+	ColumnExpr Expr
 }
 type Value struct {
 	Column string
@@ -21,6 +34,9 @@ type Value struct {
 type OrderBy struct {
 	Column string
 	Type   string
+
+	// ColumnExpr substitui Column por uma expressão com parâmetros, ex: `query.Raw("similarity(name, ?)", term)`.
+	ColumnExpr Expr
 }
 
 type JoinType string
@@ -32,6 +48,21 @@ const (
 	FullJoin  JoinType = "FULL JOIN"
 )
 
+type EmptyInBehavior int
+
+const (
+	// EmptyInConstant renderiza `IN ()` como FALSE e `NOT IN ()` como TRUE.
+	EmptyInConstant EmptyInBehavior = iota
+	// EmptyInError renderiza o mesmo predicado constante e registra ErrEmptyIn em Err().
+	EmptyInError
+)
+
+var ErrEmptyIn = errors.New("query: empty slice in IN condition")
+
+// ErrStaleVersion é retornado pelos executores quando um UPDATE / DELETE com OptimisticLock não altera nenhum registro,
+// indicando que a versão esperada não é mais a atual.
+var ErrStaleVersion = errors.New("query: stale version")
+
 type Join struct {
 	Table string
 	As    string
@@ -42,18 +73,44 @@ type Join struct {
 type QueryBuilder struct {
 	config Config
 
-	otelSpan trace.Span
+	otelSpan  trace.Span
+	redaction *RedactionPolicy
+	metrics   *buildMetrics
+	hook      Hook
 
 	from      string
-	selects   []string
+	table     string
+	alias     string
+	selects   []Expr
 	values    []Value
 	joins     []Join
 	wheresAnd [][]Where
 	wheresOr  [][]Where
 	limit     *int
 	offset    *int
-	groupBy   []string
+	groupBy   []Expr
 	orderBys  []OrderBy
+
+	withoutTenant bool
+	deleted       deletedMode
+	version       *any
+
+	// err é o erro da última chamada de um método To*, retornado por Err
+	errMu sync.Mutex
+	err   error
+}
+
+// Statement é o resultado de uma renderização, retornado pelos métodos Build*.
+type Statement struct {
+	// Operation é o valor do atributo db.operation.name: SELECT, UPDATE ou DELETE.
+	Operation string
+	SQL       string
+	Args      []any
+	// Columns é a coluna de cada parâmetro de Args, utilizada pela política de redação.
+	Columns []string
+	// Text é a query registrada no span e enviada ao Hook. Com ParseWhere(false) e uma política de redação, os
+	// valores inseridos no SQL são substituídos por placeholders; fica vazio quando a query não pode ser redigida.
+	Text string
 }
 
 func NewQueryBuilder(configs ...QueryBuilderConfig) *QueryBuilder {
@@ -73,76 +130,148 @@ func NewQueryBuilder(configs ...QueryBuilderConfig) *QueryBuilder {
 }
 
 func (q *QueryBuilder) From(from ...string) *QueryBuilder {
+	q = q.builder()
 	if len(from) == 1 {
 		q.from = fmt.Sprintf(`"%s"`, from[0])
 	}
 	if len(from) == 2 {
 		q.from = fmt.Sprintf(`"%s" AS "%s"`, from[0], from[1])
 	}
+	q.table = from[0]
+	q.alias = ""
+	if len(from) == 2 {
+		q.alias = from[1]
+	}
 	q.setSpanAttribute("db.collection.name", from[0])
 	return q
 }
 func (q *QueryBuilder) Select(selects ...string) *QueryBuilder {
-	q.selects = append(q.selects, selects...)
+	q = q.builder()
+	q.selects = append(q.selects, columnExprs(selects)...)
 	q.setSpanAttribute("db.operation.name", "SELECT")
 	return q
 }
 func (q *QueryBuilder) Values(values ...Value) *QueryBuilder {
+	q = q.builder()
 	q.values = append(q.values, values...)
 	return q
 }
 func (q *QueryBuilder) ClearSelect() *QueryBuilder {
-	q.selects = make([]string, 0)
+	q = q.builder()
+	q.selects = make([]Expr, 0)
 	return q
 }
 func (q *QueryBuilder) Join(join Join) *QueryBuilder {
+	q = q.builder()
 	q.joins = append(q.joins, join)
 	return q
 }
 func (q *QueryBuilder) WhereAnd(where ...Where) *QueryBuilder {
+	q = q.builder()
 	q.wheresAnd = append(q.wheresAnd, where)
 	return q
 }
 func (q *QueryBuilder) WhereOr(where ...Where) *QueryBuilder {
+	q = q.builder()
 	q.wheresOr = append(q.wheresOr, where)
 	return q
 }
 func (q *QueryBuilder) PaginationPaged(page int, pageSize int) *QueryBuilder {
+	q = q.builder()
 	q.limit = &pageSize
 	offset := (page - 1) * pageSize
 	q.offset = &offset
 	return q
 }
 func (q *QueryBuilder) Limit(limit int) *QueryBuilder {
+	q = q.builder()
 	q.limit = &limit
 	return q
 }
 func (q *QueryBuilder) Offset(offset int) *QueryBuilder {
+	q = q.builder()
 	q.offset = &offset
 	return q
 }
 func (q *QueryBuilder) OrderBy(orderBy OrderBy) *QueryBuilder {
+	q = q.builder()
 	q.orderBys = append(q.orderBys, orderBy)
 	return q
 }
 func (q *QueryBuilder) ClearOrderBy() *QueryBuilder {
+	q = q.builder()
 	q.orderBys = make([]OrderBy, 0)
 	return q
 }
 func (q *QueryBuilder) GroupBy(groupBy ...string) *QueryBuilder {
-	q.groupBy = groupBy
+	q = q.builder()
+	q.groupBy = append(q.groupBy, columnExprs(groupBy)...)
+	return q
+}
+func (q *QueryBuilder) ClearGroupBy() *QueryBuilder {
+	q = q.builder()
+	q.groupBy = make([]Expr, 0)
 	return q
 }
 
 func (q *QueryBuilder) ToSelectSql() (query string, queryData []interface{}) {
+	return q.result(q.BuildSelect())
+}
+
+// ToSelectWithTotalSql gera a mesma query de ToSelectSql com a coluna adicional `total`, calculada com
+// `COUNT(*) OVER()`, permitindo obter a página e o total de registros em uma única consulta.
+//
+// A coluna `total` é sempre a última coluna retornada.
+//
+// Exemplo de uso:
+//
+//	sql, params := query.NewQueryBuilder().From("users").Select("id").PaginationPaged(2, 10).ToSelectWithTotalSql()
+//	// SQL: SELECT id, COUNT(*) OVER() AS total FROM "users" LIMIT 10 OFFSET 10
+func (q *QueryBuilder) ToSelectWithTotalSql() (query string, queryData []interface{}) {
+	return q.result(q.BuildSelectWithTotal())
+}
+
+// BuildSelect renderiza a mesma query de ToSelectSql, retornando o resultado e o erro da renderização em vez de
+// registrá-lo em Err. Como nada é armazenado no builder, pode ser utilizado por várias goroutines ao mesmo tempo.
+//
+// Exemplo de uso:
+//
+//	stmt, err := qb.BuildSelect()
+//	if err != nil {
+//	    return err
+//	}
+//	rows, err := db.QueryContext(ctx, stmt.SQL, stmt.Args...)
+func (q *QueryBuilder) BuildSelect() (Statement, error) {
+	return q.toSelectSql()
+}
+
+// BuildSelectWithTotal é a versão de ToSelectWithTotalSql que retorna o erro da renderização (veja BuildSelect).
+func (q *QueryBuilder) BuildSelectWithTotal() (Statement, error) {
+	return q.toSelectSql("COUNT(*) OVER() AS total")
+}
+func (q *QueryBuilder) toSelectSql(extraSelects ...string) (Statement, error) {
+	start := time.Now()
 	qb := strings.Builder{}
 
+	var (
+		itemNum int
+		columns = make([]string, 0)
+		errs    []error
+	)
+	queryData := make([]interface{}, 0)
+
 	// SELECT
 	qb.WriteString("SELECT ")
 	if len(q.selects) == 0 {
 		qb.WriteString("*")
 	} else {
-		qb.WriteString(strings.Join(q.selects, ", "))
+		selects, err := q.renderExprs(q.selects, &itemNum, &queryData, &columns)
+		errs = append(errs, err)
+		qb.WriteString(strings.Join(selects, ", "))
+	}
+	for _, item := range extraSelects {
+		qb.WriteString(", ")
+		qb.WriteString(item)
 	}
 	qb.WriteString(" ")
 
@@ -150,27 +279,23 @@ func (q *QueryBuilder) ToSelectSql() (query string, queryData []interface{}) {
 	qb.WriteString(fmt.Sprintf(`FROM %s`, q.from))
 
 	// JOIN
-	if len(q.joins) != 0 {
-		for _, item := range q.joins {
-			joinType := InnerJoin
-
-			if item.Type != "" {
-				joinType = item.Type
-			}
-
-			qb.WriteString(fmt.Sprintf(` %s "%s" AS "%s" ON %s`, joinType, item.Table, item.As, item.On))
-		}
-	}
+	qb.WriteString(q.getJoins(&itemNum, &queryData, &columns))
 
 	// WHERE
-	var where string
-	where, queryData = q.getWhere(0)
+	where, queryDataWhere, columnsWhere, err := q.getWhere(itemNum, "SELECT")
+	itemNum += len(queryDataWhere)
+	queryData = append(queryData, queryDataWhere...)
+	columns = append(columns, columnsWhere...)
 	qb.WriteString(where)
+	errs = append(errs, err)
 
 	// GROUP BY
 	if len(q.groupBy) != 0 {
+		groupBy, err := q.renderExprs(q.groupBy, &itemNum, &queryData, &columns)
+		errs = append(errs, err)
+
 		qb.WriteString(" GROUP BY ")
-		qb.WriteString(strings.Join(q.groupBy, ", "))
+		qb.WriteString(strings.Join(groupBy, ", "))
 	}
 
 	// ORDER BY
@@ -179,10 +304,16 @@ func (q *QueryBuilder) ToSelectSql() (query string, queryData []interface{}) {
 		orderBy := make([]string, 0, len(q.orderBys))
 
 		for _, item := range q.orderBys {
+			column := item.Column
+			if item.ColumnExpr.SQL != "" {
+				column, err = q.renderExpr(item.ColumnExpr, "", false, &itemNum, &queryData, &columns)
+				errs = append(errs, err)
+			}
+
 			if item.Type == "" {
-				orderBy = append(orderBy, item.Column)
+				orderBy = append(orderBy, column)
 			} else {
-				orderBy = append(orderBy, fmt.Sprintf("%s %s", item.Column, strings.ToUpper(item.Type)))
+				orderBy = append(orderBy, fmt.Sprintf("%s %s", column, strings.ToUpper(item.Type)))
 			}
 		}
 
@@ -199,13 +330,22 @@ func (q *QueryBuilder) ToSelectSql() (query string, queryData []interface{}) {
 		qb.WriteString(fmt.Sprintf(" OFFSET %d", *q.offset))
 	}
 
-	query = qb.String()
+	query := qb.String()
+	err = q.validate("SELECT", query, errors.Join(errs...))
 
-	q.setSpanAttribute("db.query.text", query)
+	stmt := Statement{Operation: "SELECT", SQL: query, Args: queryData, Columns: columns, Text: q.queryText(query)}
+	q.setSpanText("db.query.text", stmt.Text)
+	q.finishBuild(start, stmt, err)
 
-	return query, queryData
+	return stmt, err
 }
 func (q *QueryBuilder) ToSelectTotalSql() (query string, queryData []interface{}) {
+	return q.result(q.BuildSelectTotal())
+}
+
+// BuildSelectTotal é a versão de ToSelectTotalSql que retorna o erro da renderização (veja BuildSelect).
+func (q *QueryBuilder) BuildSelectTotal() (Statement, error) {
+	start := time.Now()
 	qb := strings.Builder{}
 
 	// SELECT
@@ -216,30 +356,38 @@ func (q *QueryBuilder) ToSelectTotalSql() (query string, queryData []interface{}
 	qb.WriteString(fmt.Sprintf(`FROM %s`, q.from))
 
 	// JOIN
-	if len(q.joins) != 0 {
-		for _, item := range q.joins {
-			joinType := InnerJoin
-
-			if item.Type != "" {
-				joinType = item.Type
-			}
-
-			qb.WriteString(fmt.Sprintf(` %s "%s" AS "%s" ON %s`, joinType, item.Table, item.As, item.On))
-		}
-	}
+	var itemNum int
+	queryData := make([]interface{}, 0)
+	columns := make([]string, 0)
+	qb.WriteString(q.getJoins(&itemNum, &queryData, &columns))
 
 	// WHERE
-	var where string
-	where, queryData = q.getWhere(0)
+	where, queryDataWhere, columnsWhere, err := q.getWhere(itemNum, "SELECT")
+	queryData = append(queryData, queryDataWhere...)
+	columns = append(columns, columnsWhere...)
 	qb.WriteString(where)
 
-	query = qb.String()
-	qb = strings.Builder{}
+	query := qb.String()
+	err = q.validate("SELECT", query, err)
 
-	return query, queryData
+	stmt := Statement{Operation: "SELECT", SQL: query, Args: queryData, Columns: columns, Text: q.queryText(query)}
+	q.finishBuild(start, stmt, err)
+
+	return stmt, err
 }
 
 func (q *QueryBuilder) ToUpdateQuery() (query string, queryData []interface{}) {
+	return q.result(q.BuildUpdate())
+}
+
+// BuildUpdate é a versão de ToUpdateQuery que retorna o erro da renderização (veja BuildSelect).
+func (q *QueryBuilder) BuildUpdate() (Statement, error) {
+	return q.toUpdateQuery(time.Now(), "UPDATE", q.values)
+}
+
+// toUpdateQuery renderiza o UPDATE de setValues. operation define as condições automáticas do WHERE (veja getWhere):
+// DELETE na remoção lógica de BuildDelete.
+func (q *QueryBuilder) toUpdateQuery(start time.Time, operation string, setValues []Value) (Statement, error) {
 	qb := strings.Builder{}
 
 	qb.WriteString("UPDATE ")
@@ -249,38 +397,162 @@ func (q *QueryBuilder) ToUpdateQuery() (query string, queryData []interface{}) {
 
 	// VALUES
 	var itemNum int
+	var queryData []interface{}
+	var columns []string
+
+	var errs []error
+
+	values := make([]string, 0, len(setValues)+1)
+	for _, item := range setValues {
+		if expr, ok := item.Val.(Expr); ok {
+			value, err := q.renderExpr(expr, item.Column, false, &itemNum, &queryData, &columns)
+			errs = append(errs, err)
+			values = append(values, fmt.Sprintf(`%s = %s`, item.Column, value))
+			continue
+		}
 
-	values := make([]string, 0, len(q.values))
-	for _, item := range q.values {
 		itemNum++
 		values = append(values, fmt.Sprintf(`%s = $%d`, item.Column, itemNum))
 		queryData = append(queryData, item.Val)
+		columns = append(columns, item.Column)
+	}
+	if q.config.lockColumn != "" {
+		values = append(values, fmt.Sprintf(`%s = %s + 1`, q.config.lockColumn, q.config.lockColumn))
 	}
 	qb.WriteString(strings.Join(values, ", "))
 
 	// WHERE
-	var where string
-	where, queryDataWhere := q.getWhere(itemNum)
+	where, queryDataWhere, columnsWhere, err := q.getWhere(itemNum, operation)
 	queryData = append(queryData, queryDataWhere...)
+	columns = append(columns, columnsWhere...)
+	qb.WriteString(where)
+
+	query := qb.String()
+	err = q.validate("UPDATE", query, errors.Join(append(errs, err)...))
+
+	stmt := Statement{Operation: "UPDATE", SQL: query, Args: queryData, Columns: columns, Text: q.queryText(query)}
+	q.setSpanText("db.operation.text", stmt.Text)
+	q.finishBuild(start, stmt, err)
+
+	return stmt, err
+}
+
+// ToDeleteQuery gera a query DELETE com as condições definidas em WhereAnd / WhereOr.
+//
+// Quando a tabela de From foi configurada com SoftDelete, gera um UPDATE que preenche a coluna de remoção com o
+// horário atual, junto aos valores definidos em Values (ex: deleted_by).
+//
+// Exemplo de uso:
+//
+//	sql, params := query.NewQueryBuilder().From("users").WhereAnd(query.Where{Column: "id", Type: "=", Val: 1}).ToDeleteQuery()
+//	// SQL: DELETE FROM "users" WHERE (id = $1)
+func (q *QueryBuilder) ToDeleteQuery() (query string, queryData []interface{}) {
+	return q.result(q.BuildDelete())
+}
+
+// BuildDelete é a versão de ToDeleteQuery que retorna o erro da renderização (veja BuildSelect).
+func (q *QueryBuilder) BuildDelete() (Statement, error) {
+	start := time.Now()
+
+	if column, ok := q.softDeleteColumn(); ok {
+		values := append(slices.Clone(q.values), Value{Column: column, Val: start})
+		return q.toUpdateQuery(start, "DELETE", values)
+	}
+
+	qb := strings.Builder{}
+
+	qb.WriteString(fmt.Sprintf(`DELETE FROM %s`, q.from))
+
+	// WHERE
+	where, queryData, columns, err := q.getWhere(0, "DELETE")
 	qb.WriteString(where)
 
-	query = qb.String()
+	query := qb.String()
+	err = q.validate("DELETE", query, err)
 
-	q.setSpanAttribute("db.operation.text", query)
+	stmt := Statement{Operation: "DELETE", SQL: query, Args: queryData, Columns: columns, Text: q.queryText(query)}
+	q.setSpanText("db.operation.text", stmt.Text)
+	q.finishBuild(start, stmt, err)
 
-	return query, queryData
+	return stmt, err
 }
 
-func (q *QueryBuilder) getWhere(itemNum int) (string, []interface{}) {
+// getJoins renderiza os JOINs. Com Tenant e SoftDelete, as condições de cada tabela são adicionadas ao ON,
+// preservando o comportamento de LEFT / RIGHT / FULL JOIN.
+func (q *QueryBuilder) getJoins(itemNum *int, queryData *[]interface{}, columns *[]string) string {
+	qb := strings.Builder{}
+	for _, item := range q.joins {
+		joinType := InnerJoin
+
+		if item.Type != "" {
+			joinType = item.Type
+		}
+
+		// Condições aplicadas automaticamente, com o ON informado entre parênteses para que um OR não escape delas
+		on := item.On
+		scopes := make([]string, 0, 2)
+		if q.tenantScoped() {
+			scopes = append(scopes, q.tenantCondition(item.As, itemNum, queryData, columns))
+		}
+		if softDelete := q.joinSoftDeleteCondition(item); softDelete != "" {
+			scopes = append(scopes, softDelete)
+		}
+		if len(scopes) != 0 {
+			on = fmt.Sprintf("(%s) AND %s", on, strings.Join(scopes, " AND "))
+		}
+
+		qb.WriteString(fmt.Sprintf(` %s "%s" AS "%s" ON %s`, joinType, item.Table, item.As, on))
+	}
+
+	return qb.String()
+}
+
+// getWhere retorna também a coluna de cada parâmetro, utilizada na redação dos valores enviados ao Hook.
+//
+// operation define as condições automáticas: remoção lógica nas queries SELECT, versão esperada (OptimisticLock)
+// nas queries UPDATE / DELETE e, no DELETE de uma tabela com remoção lógica, somente os registros não removidos.
+func (q *QueryBuilder) getWhere(itemNum int, operation string) (string, []interface{}, []string, error) {
 	queryData := make([]interface{}, 0)
+	columns := make([]string, 0)
+
+	// Condições aplicadas automaticamente pelas configurações do builder (Tenant, SoftDelete, OptimisticLock)
+	scopes := make([]string, 0, 2)
+	if q.tenantScoped() {
+		scopes = append(scopes, q.tenantCondition(q.fromAlias(), &itemNum, &queryData, &columns))
+	}
+	switch operation {
+	case "SELECT":
+		if softDelete := q.softDeleteCondition(); softDelete != "" {
+			scopes = append(scopes, softDelete)
+		}
+	case "DELETE":
+		// Na remoção lógica, mantém o horário de registros já removidos
+		if column, ok := q.softDeleteColumn(); ok {
+			scopes = append(scopes, fmt.Sprintf(`"%s".%s IS NULL`, q.fromAlias(), column))
+		}
+	}
+	if version, ok := q.ExpectedVersion(); ok && operation != "SELECT" {
+		scopes = append(scopes, q.equalCondition(q.fromAlias(), q.config.lockColumn, version, &itemNum, &queryData, &columns))
+	}
+	scope := strings.Join(scopes, " AND ")
 
 	if len(q.wheresOr) == 0 && len(q.wheresAnd) == 0 {
-		return "", queryData
+		if scope != "" {
+			return " WHERE " + scope, queryData, columns, nil
+		}
+		return "", queryData, columns, nil
 	}
 
+	var errs []error
+
 	qb := strings.Builder{}
 
 	qb.WriteString(" WHERE ")
+	if scope != "" {
+		// As condições do usuário ficam entre parênteses para que um OR não escape dos filtros automáticos
+		qb.WriteString(scope)
+		qb.WriteString(" AND (")
+	}
 
 	wheresToOr := make([]string, 0)
 
@@ -288,7 +560,8 @@ func (q *QueryBuilder) getWhere(itemNum int) (string, []interface{}) {
 		whereAndBuilder := make([]string, 0)
 
 		for _, whereAnd := range q.wheresAnd {
-			wheres := q.parseWhere(whereAnd, &itemNum, &queryData)
+			wheres, err := q.parseWhere(whereAnd, &itemNum, &queryData, &columns)
+			errs = append(errs, err)
 			whereAndBuilder = append(whereAndBuilder, fmt.Sprintf("(%s)", strings.Join(wheres, " AND ")))
 		}
 
@@ -296,43 +569,97 @@ func (q *QueryBuilder) getWhere(itemNum int) (string, []interface{}) {
 	}
 	if len(q.wheresOr) != 0 {
 		for _, whereAnd := range q.wheresOr {
-			wheres := q.parseWhere(whereAnd, &itemNum, &queryData)
+			wheres, err := q.parseWhere(whereAnd, &itemNum, &queryData, &columns)
+			errs = append(errs, err)
 			wheresToOr = append(wheresToOr, fmt.Sprintf("(%s)", strings.Join(wheres, " AND ")))
 		}
 	}
 
 	qb.WriteString(strings.Join(wheresToOr, " OR "))
+	if scope != "" {
+		qb.WriteString(")")
+	}
 
-	return qb.String(), queryData
+	return qb.String(), queryData, columns, errors.Join(errs...)
 }
 
-func (q *QueryBuilder) parseWhere(whereAnd []Where, itemNum *int, queryData *[]interface{}) []string {
+func (q *QueryBuilder) parseWhere(whereAnd []Where, itemNum *int, queryData *[]interface{}, columns *[]string) ([]string, error) {
 	wheres := make([]string, 0, len(q.wheresAnd))
 
+	var errs []error
+
 	for _, item := range whereAnd {
+		if item.Not {
+			item.Not = false
+			inner, err := q.parseWhere([]Where{item}, itemNum, queryData, columns)
+			errs = append(errs, err)
+
+			// Grupos com somente And ou Or já são renderizados entre parênteses
+			if (len(item.And) == 0) != (len(item.Or) == 0) {
+				wheres = append(wheres, "NOT "+inner[0])
+			} else {
+				wheres = append(wheres, fmt.Sprintf("NOT (%s)", inner[0]))
+			}
+			continue
+		}
+
+		if len(item.And) != 0 || len(item.Or) != 0 {
+			group, err := q.parseWhereGroup(item, itemNum, queryData, columns)
+			errs = append(errs, err)
+			wheres = append(wheres, group)
+			continue
+		}
+
 		Type := strings.ToUpper(item.Type)
 
+		column := item.Column
+		if item.ColumnExpr.SQL != "" {
+			var err error
+			column, err = q.renderExpr(item.ColumnExpr, item.Column, !q.config.parseWhere, itemNum, queryData, columns)
+			errs = append(errs, err)
+		}
+
 		var val string
 
-		if item.Val != nil {
+		if expr, ok := item.Val.(Expr); ok {
+			var err error
+			val, err = q.renderExpr(expr, item.Column, !q.config.parseWhere, itemNum, queryData, columns)
+			errs = append(errs, err)
+		} else if item.Val != nil {
 			if reflect.TypeOf(item.Val).Kind() == reflect.Slice {
 				values := make([]string, 0)
 				s := reflect.ValueOf(item.Val)
 
-				spanItemValues := make([]string, 0, s.Len())
+				if s.Len() == 0 && (Type == "IN" || Type == "NOT IN") {
+					// `col IN ()` é inválido no Postgres, então usamos o predicado constante equivalente
+					q.setSpanAttribute("db.query.empty_in."+item.Column, Type)
+					if q.config.emptyIn == EmptyInError {
+						errs = append(errs, fmt.Errorf("%w: %s %s", ErrEmptyIn, item.Column, Type))
+					}
+
+					if Type == "IN" {
+						wheres = append(wheres, "FALSE")
+					} else {
+						wheres = append(wheres, "TRUE")
+					}
+					continue
+				}
+
+				spanItemValues := make([]any, 0, s.Len())
 				for i := 0; i < s.Len(); i++ {
 					value := s.Index(i).Interface()
 
 					if q.config.parseWhere {
 						(*itemNum)++
 						*queryData = append(*queryData, value)
+						*columns = append(*columns, item.Column)
 						values = append(values, fmt.Sprintf("$%d", *itemNum))
 					} else {
 						values = append(values, q.getWhereValue(value))
 					}
-					spanItemValues = append(spanItemValues, fmt.Sprint(value))
+					spanItemValues = append(spanItemValues, value)
 				}
-				q.setSpanAttributeSlice("db.query.parameter."+item.Column, spanItemValues)
+				q.setSpanParameterSlice(item.Column, spanItemValues)
 
 				if Type == "IN" || Type == "NOT IN" {
 					val = fmt.Sprintf("(%s)", strings.Join(values, ", "))
@@ -340,10 +667,11 @@ func (q *QueryBuilder) parseWhere(whereAnd []Where, itemNum *int, queryData *[]i
 					val = strings.Join(values, " AND ")
 				}
 			} else {
-				q.setSpanAttribute("db.query.parameter."+item.Column, fmt.Sprint(item.Val))
+				q.setSpanParameter(item.Column, item.Val)
 				if q.config.parseWhere {
 					(*itemNum)++
 					*queryData = append(*queryData, item.Val)
+					*columns = append(*columns, item.Column)
 					val = fmt.Sprintf("$%d", *itemNum)
 				} else {
 					val = q.getWhereValue(item.Val)
@@ -351,14 +679,52 @@ func (q *QueryBuilder) parseWhere(whereAnd []Where, itemNum *int, queryData *[]i
 			}
 		}
 
-		if val == "" {
-			wheres = append(wheres, fmt.Sprintf(`%s %s`, item.Column, Type))
-		} else {
-			wheres = append(wheres, fmt.Sprintf(`%s %s %s`, item.Column, Type, val))
+		switch {
+		case val == "" && Type == "":
+			// Expressão completa, ex: Where{ColumnExpr: query.Raw("tsv @@ plainto_tsquery(?)", term)}
+			wheres = append(wheres, column)
+		case val == "":
+			wheres = append(wheres, fmt.Sprintf(`%s %s`, column, Type))
+		default:
+			wheres = append(wheres, fmt.Sprintf(`%s %s %s`, column, Type, val))
 		}
 	}
 
-	return wheres
+	return wheres, errors.Join(errs...)
+}
+func (q *QueryBuilder) parseWhereGroup(item Where, itemNum *int, queryData *[]interface{}, columns *[]string) (string, error) {
+	groups := make([]string, 0, 2)
+
+	and, errAnd := q.parseWhere(item.And, itemNum, queryData, columns)
+	if len(and) != 0 {
+		groups = append(groups, fmt.Sprintf("(%s)", strings.Join(and, " AND ")))
+	}
+
+	or, errOr := q.parseWhere(item.Or, itemNum, queryData, columns)
+	if len(or) != 0 {
+		groups = append(groups, fmt.Sprintf("(%s)", strings.Join(or, " OR ")))
+	}
+
+	return strings.Join(groups, " AND "), errors.Join(errAnd, errOr)
+}
+
+// equalCondition retorna `"alias".column = $n`, ou o valor inline com ParseWhere(false), para as condições
+// automáticas. O alias é escrito entre aspas como em From e Join, preservando maiúsculas, e o valor inline é
+// codificado como literal do Postgres.
+func (q *QueryBuilder) equalCondition(alias, column string, value any, itemNum *int, queryData *[]interface{}, columns *[]string) string {
+	name := fmt.Sprintf("%s.%s", alias, column)
+	qualified := fmt.Sprintf(`"%s".%s`, alias, column)
+
+	q.setSpanParameter(name, value)
+	if !q.config.parseWhere {
+		return fmt.Sprintf("%s = %s", qualified, literal(value))
+	}
+
+	(*itemNum)++
+	*queryData = append(*queryData, value)
+	*columns = append(*columns, name)
+
+	return fmt.Sprintf("%s = $%d", qualified, *itemNum)
 }
 func (q *QueryBuilder) getWhereValue(val any) (resp string) {
 	switch val.(type) {
@@ -370,15 +736,38 @@ func (q *QueryBuilder) getWhereValue(val any) (resp string) {
 
 	return resp
 }
+
+// result registra err para Err e retorna a query, mantendo a assinatura dos métodos To*.
+func (q *QueryBuilder) result(stmt Statement, err error) (string, []interface{}) {
+	q.errMu.Lock()
+	defer q.errMu.Unlock()
+	q.err = err
+
+	return stmt.SQL, stmt.Args
+}
+
+// finishBuild registra o resultado de uma renderização no span, nas métricas e no Hook.
+func (q *QueryBuilder) finishBuild(start time.Time, stmt Statement, err error) {
+	q.setSpanQuery(stmt.SQL)
+	q.setSpanTenant()
+	q.recordBuild(stmt.Operation, start, stmt.Args, err)
+	q.emitBuild(start, stmt, err)
+}
 func (q *QueryBuilder) setSpanAttribute(key, val string) {
 	if q.otelSpan != nil {
 		q.otelSpan.SetAttributes(attribute.String(key, val))
 	}
 }
 func (q *QueryBuilder) setSpanAttributeSlice(key string, val []string) {
-	if q.otelSpan != nil {
-		q.otelSpan.SetAttributes(attribute.StringSlice(key, val))
+	if q.otelSpan == nil {
+		return
+	}
+
+	if q.redaction != nil && q.redaction.MaxSliceLen != 0 && len(val) > q.redaction.MaxSliceLen {
+		q.otelSpan.SetAttributes(attribute.Int(key+".length", len(val)))
+		val = val[:q.redaction.MaxSliceLen]
 	}
+	q.otelSpan.SetAttributes(attribute.StringSlice(key, val))
 }
 
 // Utils
@@ -399,3 +788,36 @@ func (q *QueryBuilder) setSpanAttributeSlice(key string, val []string) {
 func (q *QueryBuilder) HasValues() bool {
 	return len(q.values) != 0
 }
+
+// Table retorna o nome da tabela informado em From, sem aspas e sem alias.
+//
+// É o mesmo valor registrado no atributo `db.collection.name` do span.
+func (q *QueryBuilder) Table() string {
+	return q.table
+}
+
+// Err retorna o erro encontrado na última chamada de ToSelectSql, ToSelectTotalSql, ToSelectWithTotalSql,
+// ToUpdateQuery ou ToDeleteQuery.
+//
+// Os renderizadores sempre retornam uma query; Err permite identificar quando essa query foi gerada a partir de
+// filtros inválidos, como um IN com slice vazio quando configurado com EmptyIn(EmptyInError), ou um SQL inválido
+// quando configurado com ValidateSQL.
+//
+// Err é mantido por compatibilidade: como o erro fica armazenado no builder, renderizações de outras goroutines (ou de
+// outra query do mesmo builder) o substituem. Prefira os métodos Build*, que retornam o erro de cada renderização.
+//
+// Exemplo de uso:
+//
+//	qb := query.NewQueryBuilder(query.EmptyIn(query.EmptyInError)).
+//	    From("users").
+//	    WhereAnd(query.Where{Column: "id", Type: "IN", Val: ids})
+//
+//	sql, params := qb.ToSelectSql()
+//	if err := qb.Err(); err != nil {
+//	    return err
+//	}
+func (q *QueryBuilder) Err() error {
+	q.errMu.Lock()
+	defer q.errMu.Unlock()
+	return q.err
+}
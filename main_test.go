@@ -2,6 +2,7 @@ package query
 
 import (
 	"context"
+	"sync"
 	"testing"
 
 	pg_query "github.com/pganalyze/pg_query_go/v6"
@@ -194,6 +195,50 @@ func TestNewQueryBuilderSelect(t *testing.T) {
 			resultTotal: `SELECT COUNT(*) AS total FROM "users" WHERE (age IS NULL)`,
 			args:        []interface{}{},
 		},
+		{
+			title: "Test Where Nested Group",
+			data: NewQueryBuilder().From("users").Select("*").WhereAnd(
+				Where{Column: "active", Type: "=", Val: true},
+				Where{Or: []Where{
+					{Column: "age", Type: "<", Val: 18},
+					{And: []Where{{Column: "age", Type: ">", Val: 65}, {Column: "retired", Type: "=", Val: true}}},
+				}},
+			),
+			result:      `SELECT * FROM "users" WHERE (active = $1 AND (age < $2 OR (age > $3 AND retired = $4)))`,
+			resultTotal: `SELECT COUNT(*) AS total FROM "users" WHERE (active = $1 AND (age < $2 OR (age > $3 AND retired = $4)))`,
+			args:        []interface{}{true, 18, 65, true},
+		},
+		{
+			title: "Test Where Not",
+			data: NewQueryBuilder().From("users").Select("*").WhereAnd(
+				Where{Column: "name", Type: "like", Val: "%Mark%", Not: true},
+				Where{Not: true, Or: []Where{{Column: "age", Type: "<", Val: 18}, {Column: "status", Type: "in", Val: []string{}}}},
+			),
+			result:      `SELECT * FROM "users" WHERE (NOT (name LIKE $1) AND NOT (age < $2 OR FALSE))`,
+			resultTotal: `SELECT COUNT(*) AS total FROM "users" WHERE (NOT (name LIKE $1) AND NOT (age < $2 OR FALSE))`,
+			args:        []interface{}{"%Mark%", 18},
+		},
+		{
+			title:       "Test Where Nested Group Empty IN",
+			data:        NewQueryBuilder().From("users").Select("*").WhereOr(Where{Or: []Where{{Column: "status", Type: "in", Val: []string{}}, {Column: "age", Type: "between", Val: []int{1, 2}}}}),
+			result:      `SELECT * FROM "users" WHERE ((FALSE OR age BETWEEN $1 AND $2))`,
+			resultTotal: `SELECT COUNT(*) AS total FROM "users" WHERE ((FALSE OR age BETWEEN $1 AND $2))`,
+			args:        []interface{}{1, 2},
+		},
+		{
+			title:       "Test Where IN Empty",
+			data:        NewQueryBuilder().From("users").Select("*").WhereAnd(Where{Column: "age", Type: ">", Val: 18}, Where{Column: "status", Type: "in", Val: []string{}}),
+			result:      `SELECT * FROM "users" WHERE (age > $1 AND FALSE)`,
+			resultTotal: `SELECT COUNT(*) AS total FROM "users" WHERE (age > $1 AND FALSE)`,
+			args:        []interface{}{18},
+		},
+		{
+			title:       "Test Where NOT IN Empty",
+			data:        NewQueryBuilder().From("users").Select("*").WhereAnd(Where{Column: "status", Type: "not in", Val: []int{}}).WhereOr(Where{Column: "age", Type: "=", Val: 18}),
+			result:      `SELECT * FROM "users" WHERE (TRUE) OR (age = $1)`,
+			resultTotal: `SELECT COUNT(*) AS total FROM "users" WHERE (TRUE) OR (age = $1)`,
+			args:        []interface{}{18},
+		},
 
 		// Group By
 		{
@@ -298,6 +343,76 @@ func TestNewQueryBuilderSelect(t *testing.T) {
 		})
 	}
 
+	t.Run("Validate Select With Total", func(t *testing.T) {
+		qb := NewQueryBuilder().From("users").Select("id", "name").WhereAnd(Where{Column: "age", Type: ">", Val: 18}).OrderBy(OrderBy{Column: "name"}).PaginationPaged(2, 10)
+
+		query, args := qb.ToSelectWithTotalSql()
+
+		assert.Equal(t, `SELECT id, name, COUNT(*) OVER() AS total FROM "users" WHERE (age > $1) ORDER BY name LIMIT 10 OFFSET 10`, query)
+		assert.Equal(t, []interface{}{18}, args)
+
+		query, _ = NewQueryBuilder().From("users").ToSelectWithTotalSql()
+
+		assert.Equal(t, `SELECT *, COUNT(*) OVER() AS total FROM "users"`, query)
+
+		_, err := pg_query.Parse(query)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Validate Table", func(t *testing.T) {
+		assert.Equal(t, "users", NewQueryBuilder().From("users", "u").Table())
+		assert.Equal(t, "", NewQueryBuilder().Table())
+	})
+
+	t.Run("Validate Empty IN Error", func(t *testing.T) {
+		qb := NewQueryBuilder(EmptyIn(EmptyInError)).From("users").WhereAnd(Where{Column: "status", Type: "in", Val: []string{}})
+
+		query, args := qb.ToSelectSql()
+
+		assert.Equal(t, `SELECT * FROM "users" WHERE (FALSE)`, query)
+		assert.Equal(t, []interface{}{}, args)
+		assert.ErrorIs(t, qb.Err(), ErrEmptyIn)
+		assert.ErrorContains(t, qb.Err(), "status IN")
+
+		qb = NewQueryBuilder().From("users").WhereAnd(Where{Column: "status", Type: "in", Val: []string{}})
+		qb.ToSelectSql()
+
+		assert.NoError(t, qb.Err())
+	})
+
+	t.Run("Validate Build Statement", func(t *testing.T) {
+		base := NewQueryBuilder(Immutable(), EmptyIn(EmptyInError)).From("users").Select("id")
+		invalid := base.WhereAnd(Where{Column: "status", Type: "in", Val: []string{}})
+		valid := base.WhereAnd(Where{Column: "email", Type: "=", Val: "john@doe.com"})
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_, err := invalid.BuildSelect()
+				assert.ErrorIs(t, err, ErrEmptyIn)
+			}()
+			go func() {
+				defer wg.Done()
+				stmt, err := valid.BuildSelect()
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"email"}, stmt.Columns)
+			}()
+		}
+		wg.Wait()
+
+		stmt, err := valid.BuildSelectTotal()
+		require.NoError(t, err)
+		assert.Equal(t, Statement{
+			Operation: "SELECT",
+			SQL:       `SELECT COUNT(*) AS total FROM "users" WHERE (email = $1)`,
+			Args:      []any{"john@doe.com"},
+			Columns:   []string{"email"},
+			Text:      `SELECT COUNT(*) AS total FROM "users" WHERE (email = $1)`,
+		}, stmt)
+	})
+
 	t.Run("Validate Otel Span Attribute", func(t *testing.T) {
 		spanRecorder := tracetest.NewSpanRecorder()
 		provider := trace.NewTracerProvider(
@@ -347,6 +462,35 @@ func TestNewQueryBuilderSelect(t *testing.T) {
 
 		validateSelectQuery(t, testCase, query, args)
 	})
+
+	t.Run("Validate Otel Span Empty IN", func(t *testing.T) {
+		spanRecorder := tracetest.NewSpanRecorder()
+		provider := trace.NewTracerProvider(
+			trace.WithSpanProcessor(spanRecorder),
+		)
+		tracer := provider.Tracer("test-tracer")
+
+		_, span := tracer.Start(context.Background(), "test-span")
+		defer span.End()
+
+		stmt, err := NewQueryBuilder(SetOtelSpan(span)).Select("*").From("users").WhereAnd(
+			Where{Column: "status", Type: "in", Val: []string{"admin", "user"}},
+			Where{Column: "role", Type: "not in", Val: []string{}},
+		).BuildSelect()
+		require.NoError(t, err)
+
+		span.End()
+
+		assert.Equal(t, `SELECT * FROM "users" WHERE (status IN ($1, $2) AND TRUE)`, stmt.SQL)
+		assert.Equal(t, []any{"admin", "user"}, stmt.Args)
+
+		spans := spanRecorder.Ended()
+		require.Len(t, spans, 1)
+		attrs := spans[0].Attributes()
+
+		assert.Contains(t, attrs, attribute.StringSlice("db.query.parameter.status", []string{"admin", "user"}))
+		assert.Contains(t, attrs, attribute.String("db.query.empty_in.role", "NOT IN"))
+	})
 }
 func TestNewQueryBuilderUpdate(t *testing.T) {
 	qb := NewQueryBuilder()
@@ -449,6 +593,31 @@ func TestNewQueryBuilderUpdate(t *testing.T) {
 	})
 }
 
+func TestNewQueryBuilderDelete(t *testing.T) {
+	data := []TestCase{
+		{
+			title:  "Test Simple",
+			data:   NewQueryBuilder().From("users"),
+			result: `DELETE FROM "users"`,
+			args:   []interface{}{},
+		},
+		{
+			title:  "Test Where",
+			data:   NewQueryBuilder().From("users", "u").WhereAnd(Where{Column: "u.id", Type: "=", Val: 1}).WhereOr(Where{Column: "u.status", Type: "IN", Val: []string{"a", "b"}}),
+			result: `DELETE FROM "users" AS "u" WHERE (u.id = $1) OR (u.status IN ($2, $3))`,
+			args:   []interface{}{1, "a", "b"},
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.title, func(t *testing.T) {
+			query, args := item.data.ToDeleteQuery()
+
+			validateSelectQuery(t, item, query, args)
+		})
+	}
+}
+
 func validateSelectQuery(t *testing.T, item TestCase, query string, args []interface{}) {
 	assert.Equalf(t, query, item.result, "Invalid query")
 	assert.Equalf(t, args, item.args, "Invalid args")
@@ -0,0 +1,133 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type buildMetrics struct {
+	builds     metric.Int64Counter
+	duration   metric.Float64Histogram
+	parameters metric.Int64Histogram
+	inListSize metric.Int64Histogram
+}
+
+// SetOtelMeter registra métricas de cada renderização da query (ToSelectSql, ToSelectTotalSql,
+// ToSelectWithTotalSql, ToUpdateQuery e ToDeleteQuery) no Meter informado:
+//
+//   - query_builder.builds: número de renderizações, com error.type quando a renderização retorna erro.
+//   - query_builder.build.duration: tempo de renderização, em segundos.
+//   - query_builder.parameters: número de parâmetros da query.
+//   - query_builder.in_list.size: número de valores de cada condição IN / NOT IN.
+//
+// As métricas recebem os atributos db.operation.name e db.collection.name, com os mesmos valores registrados no span.
+//
+// Os instrumentos são criados na chamada de SetOtelMeter e compartilhados por todos os builders que recebem a
+// configuração retornada, que deve ser criada uma única vez e reutilizada.
+//
+// Exemplo de uso:
+//
+//	metrics := query.SetOtelMeter(otel.Meter("users"))
+//
+//	qb := query.NewQueryBuilder(metrics)
+func SetOtelMeter(meter metric.Meter) QueryBuilderConfig {
+	m := newBuildMetrics(meter)
+
+	return func(q *QueryBuilder) {
+		q.metrics = m
+	}
+}
+
+func newBuildMetrics(meter metric.Meter) *buildMetrics {
+	var (
+		m    buildMetrics
+		errs [4]error
+	)
+
+	m.builds, errs[0] = meter.Int64Counter("query_builder.builds",
+		metric.WithDescription("Number of rendered queries."),
+		metric.WithUnit("{query}"))
+	m.duration, errs[1] = meter.Float64Histogram("query_builder.build.duration",
+		metric.WithDescription("Duration of query rendering."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(0.00001, 0.00005, 0.0001, 0.0005, 0.001, 0.005, 0.01))
+	m.parameters, errs[2] = meter.Int64Histogram("query_builder.parameters",
+		metric.WithDescription("Number of parameters of the rendered query."),
+		metric.WithUnit("{parameter}"),
+		metric.WithExplicitBucketBoundaries(0, 1, 2, 5, 10, 20, 50, 100, 500, 1000))
+	m.inListSize, errs[3] = meter.Int64Histogram("query_builder.in_list.size",
+		metric.WithDescription("Number of values of IN and NOT IN conditions."),
+		metric.WithUnit("{value}"),
+		metric.WithExplicitBucketBoundaries(0, 1, 2, 5, 10, 20, 50, 100, 500, 1000))
+
+	// Em caso de erro a API retorna instrumentos válidos que não registram nada, então o erro só é reportado
+	for _, err := range errs {
+		if err != nil {
+			otel.Handle(err)
+		}
+	}
+
+	return &m
+}
+
+func (q *QueryBuilder) recordBuild(operation string, start time.Time, queryData []interface{}, err error) {
+	if q.metrics == nil {
+		return
+	}
+
+	ctx := context.Background()
+	attrs := []attribute.KeyValue{
+		attribute.String("db.operation.name", operation),
+		attribute.String("db.collection.name", q.table),
+	}
+	set := metric.WithAttributes(attrs...)
+
+	q.metrics.duration.Record(ctx, time.Since(start).Seconds(), set)
+	q.metrics.parameters.Record(ctx, int64(len(queryData)), set)
+
+	for _, item := range q.wheresAnd {
+		q.recordInLists(ctx, item, set)
+	}
+	for _, item := range q.wheresOr {
+		q.recordInLists(ctx, item, set)
+	}
+
+	if err != nil {
+		set = metric.WithAttributes(append(attrs, attribute.String("error.type", buildErrorType(err)))...)
+	}
+	q.metrics.builds.Add(ctx, 1, set)
+}
+func (q *QueryBuilder) recordInLists(ctx context.Context, wheres []Where, set metric.MeasurementOption) {
+	for _, item := range wheres {
+		q.recordInLists(ctx, item.And, set)
+		q.recordInLists(ctx, item.Or, set)
+
+		Type := strings.ToUpper(item.Type)
+		if Type != "IN" && Type != "NOT IN" || item.Val == nil {
+			continue
+		}
+
+		if s := reflect.ValueOf(item.Val); s.Kind() == reflect.Slice {
+			q.metrics.inListSize.Record(ctx, int64(s.Len()), set)
+		}
+	}
+}
+
+// buildErrorType retorna um valor de baixa cardinalidade para o atributo error.type.
+func buildErrorType(err error) string {
+	if errors.Is(err, ErrEmptyIn) {
+		return "empty_in"
+	}
+	if errors.Is(err, ErrInvalidSQL) {
+		return "invalid_sql"
+	}
+
+	return "_OTHER"
+}
@@ -0,0 +1,83 @@
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	meter := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test-meter")
+
+	NewQueryBuilder(SetOtelMeter(meter)).From("users", "u").WhereAnd(
+		Where{Column: "u.status", Type: "in", Val: []string{"a", "b", "c"}},
+		Where{Or: []Where{{Column: "u.role", Type: "not in", Val: []string{"x"}}, {Column: "u.age", Type: ">", Val: 18}}},
+	).ToSelectSql()
+
+	NewQueryBuilder(SetOtelMeter(meter), EmptyIn(EmptyInError)).From("users").
+		Values(Value{Column: "name", Val: "Mark"}).
+		WhereAnd(Where{Column: "id", Type: "in", Val: []int{}}).
+		ToUpdateQuery()
+
+	var data metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &data))
+	require.Len(t, data.ScopeMetrics, 1)
+
+	metrics := make(map[string]metricdata.Aggregation)
+	for _, item := range data.ScopeMetrics[0].Metrics {
+		metrics[item.Name] = item.Data
+	}
+
+	selectAttrs := attribute.NewSet(attribute.String("db.operation.name", "SELECT"), attribute.String("db.collection.name", "users"))
+	updateAttrs := attribute.NewSet(attribute.String("db.operation.name", "UPDATE"), attribute.String("db.collection.name", "users"))
+
+	builds := metrics["query_builder.builds"].(metricdata.Sum[int64]).DataPoints
+	require.Len(t, builds, 2)
+	for _, item := range builds {
+		if item.Attributes.Equals(&selectAttrs) {
+			assert.Equal(t, int64(1), item.Value)
+			continue
+		}
+
+		errorType, _ := item.Attributes.Value("error.type")
+		assert.Equal(t, "empty_in", errorType.AsString())
+	}
+
+	parameters := metrics["query_builder.parameters"].(metricdata.Histogram[int64]).DataPoints
+	require.Len(t, parameters, 2)
+	for _, item := range parameters {
+		switch {
+		case item.Attributes.Equals(&selectAttrs):
+			assert.Equal(t, int64(5), item.Sum)
+		case item.Attributes.Equals(&updateAttrs):
+			assert.Equal(t, int64(1), item.Sum)
+		default:
+			t.Errorf("unexpected attributes %v", item.Attributes.ToSlice())
+		}
+	}
+
+	inLists := metrics["query_builder.in_list.size"].(metricdata.Histogram[int64]).DataPoints
+	require.Len(t, inLists, 2)
+	for _, item := range inLists {
+		if item.Attributes.Equals(&selectAttrs) {
+			assert.Equal(t, uint64(2), item.Count)
+			assert.Equal(t, int64(4), item.Sum)
+		} else {
+			assert.Equal(t, uint64(1), item.Count)
+			assert.Equal(t, int64(0), item.Sum)
+		}
+	}
+
+	duration := metrics["query_builder.build.duration"].(metricdata.Histogram[float64]).DataPoints
+	require.Len(t, duration, 2)
+
+	// Os instrumentos ficam na configuração, compartilhados pelos builders que a recebem
+	config := SetOtelMeter(meter)
+	assert.Same(t, NewQueryBuilder(config).metrics, NewQueryBuilder(config).metrics)
+}
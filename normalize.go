@@ -0,0 +1,216 @@
+package query
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Fingerprint retorna um identificador estável para a estrutura da query, calculado pelo pg_query.
+//
+// Valores literais e parâmetros são ignorados, assim como o tamanho das listas IN: `id IN ($1, $2)` e
+// `id IN ($1, $2, $3)` possuem o mesmo fingerprint. É indicado para agrupar queries em logs e métricas.
+//
+// Exemplo de uso:
+//
+//	sql, _ := qb.ToSelectSql()
+//	fingerprint, err := query.Fingerprint(sql) // ex: "a0ead580058af585"
+func Fingerprint(sql string) (string, error) {
+	return pg_query.Fingerprint(sql)
+}
+
+// Summarize retorna um resumo de baixa cardinalidade da query, no formato `<operação> <tabelas>`, utilizado no
+// atributo db.query.summary.
+//
+// As tabelas são listadas na ordem em que aparecem, sem repetição, incluindo as tabelas de JOINs, UNIONs e
+// subqueries, tanto no FROM quanto em expressões (ex: `id IN (SELECT ...)` e EXISTS no WHERE). Quando a query
+// possui mais de um comando, os resumos são separados por `; `.
+//
+// Exemplo de uso:
+//
+//	summary, err := query.Summarize(`SELECT u.id FROM "users" AS "u" LEFT JOIN "phones" AS "p" ON p.user_id = u.id`)
+//	// summary: "SELECT users phones"
+func Summarize(sql string) (string, error) {
+	tree, err := pg_query.Parse(sql)
+	if err != nil {
+		return "", err
+	}
+
+	summaries := make([]string, 0, len(tree.Stmts))
+	for _, item := range tree.Stmts {
+		var sum summarizer
+
+		operation := sum.node(item.Stmt)
+		if operation == "" {
+			operation = firstKeyword(sql[item.StmtLocation:])
+		}
+
+		summaries = append(summaries, strings.Join(append([]string{operation}, sum.tables...), " "))
+	}
+
+	return strings.Join(summaries, "; "), nil
+}
+
+// summarizer percorre a árvore do pg_query coletando as tabelas referenciadas, sem os nomes das CTEs.
+type summarizer struct {
+	tables []string
+	ctes   []string
+}
+
+// node retorna a operação do comando e coleta as tabelas referenciadas por ele.
+func (s *summarizer) node(node *pg_query.Node) string {
+	switch {
+	case node == nil:
+		return ""
+	case node.GetSelectStmt() != nil:
+		s.selectStmt(node.GetSelectStmt())
+		return "SELECT"
+	case node.GetInsertStmt() != nil:
+		stmt := node.GetInsertStmt()
+		s.with(stmt.WithClause)
+		s.table(stmt.Relation)
+		s.node(stmt.SelectStmt)
+		return "INSERT"
+	case node.GetUpdateStmt() != nil:
+		stmt := node.GetUpdateStmt()
+		s.with(stmt.WithClause)
+		s.table(stmt.Relation)
+		s.expr(stmt.TargetList...)
+		s.from(stmt.FromClause)
+		s.expr(stmt.WhereClause)
+		return "UPDATE"
+	case node.GetDeleteStmt() != nil:
+		stmt := node.GetDeleteStmt()
+		s.with(stmt.WithClause)
+		s.table(stmt.Relation)
+		s.from(stmt.UsingClause)
+		s.expr(stmt.WhereClause)
+		return "DELETE"
+	default:
+		return ""
+	}
+}
+func (s *summarizer) selectStmt(stmt *pg_query.SelectStmt) {
+	if stmt == nil {
+		return
+	}
+
+	s.with(stmt.WithClause)
+	if stmt.Op != pg_query.SetOperation_SETOP_NONE {
+		s.selectStmt(stmt.Larg)
+		s.selectStmt(stmt.Rarg)
+		return
+	}
+
+	s.expr(stmt.TargetList...)
+	s.from(stmt.FromClause)
+	s.expr(stmt.WhereClause, stmt.HavingClause)
+}
+func (s *summarizer) with(with *pg_query.WithClause) {
+	if with == nil {
+		return
+	}
+
+	for _, item := range with.Ctes {
+		if cte := item.GetCommonTableExpr(); cte != nil {
+			s.ctes = append(s.ctes, cte.Ctename)
+			s.node(cte.Ctequery)
+		}
+	}
+}
+func (s *summarizer) from(from []*pg_query.Node) {
+	for _, item := range from {
+		switch {
+		case item.GetRangeVar() != nil:
+			s.table(item.GetRangeVar())
+		case item.GetJoinExpr() != nil:
+			s.from([]*pg_query.Node{item.GetJoinExpr().Larg, item.GetJoinExpr().Rarg})
+			s.expr(item.GetJoinExpr().Quals)
+		case item.GetRangeSubselect() != nil:
+			s.node(item.GetRangeSubselect().Subquery)
+		}
+	}
+}
+
+// expr coleta as tabelas das subqueries presentes em expressões (SubLink), como `id IN (SELECT ...)` e EXISTS.
+func (s *summarizer) expr(nodes ...*pg_query.Node) {
+	for _, item := range nodes {
+		if item == nil {
+			continue
+		}
+
+		if sublink := item.GetSubLink(); sublink != nil {
+			s.expr(sublink.Testexpr)
+			s.node(sublink.Subselect)
+			continue
+		}
+
+		s.children(item.ProtoReflect())
+	}
+}
+func (s *summarizer) children(msg protoreflect.Message) {
+	msg.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		switch {
+		case field.Message() == nil || field.IsMap():
+		case field.IsList():
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				s.message(list.Get(i).Message())
+			}
+		default:
+			s.message(value.Message())
+		}
+		return true
+	})
+}
+func (s *summarizer) message(msg protoreflect.Message) {
+	if node, ok := msg.Interface().(*pg_query.Node); ok {
+		s.expr(node)
+		return
+	}
+
+	s.children(msg)
+}
+func (s *summarizer) table(relation *pg_query.RangeVar) {
+	if relation == nil {
+		return
+	}
+
+	name := relation.Relname
+	if relation.Schemaname != "" {
+		name = fmt.Sprintf("%s.%s", relation.Schemaname, relation.Relname)
+	} else if slices.Contains(s.ctes, name) {
+		return
+	}
+
+	if !slices.Contains(s.tables, name) {
+		s.tables = append(s.tables, name)
+	}
+}
+
+func firstKeyword(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	return strings.ToUpper(strings.TrimRight(fields[0], ";"))
+}
+
+// setSpanQuery registra o resumo e o fingerprint da query no span. Como ambos dependem do pg_query, só são
+// calculados quando há um span configurado.
+func (q *QueryBuilder) setSpanQuery(query string) {
+	if q.otelSpan == nil {
+		return
+	}
+
+	if summary, err := Summarize(query); err == nil {
+		q.setSpanAttribute("db.query.summary", summary)
+	}
+	if fingerprint, err := Fingerprint(query); err == nil {
+		q.setSpanAttribute("db.query.fingerprint", fingerprint)
+	}
+}
@@ -0,0 +1,105 @@
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSummarize(t *testing.T) {
+	data := []struct {
+		title   string
+		sql     string
+		summary string
+	}{
+		{
+			title:   "Test Select",
+			sql:     `SELECT * FROM "users" WHERE (id = $1)`,
+			summary: "SELECT users",
+		},
+		{
+			title:   "Test Join",
+			sql:     `SELECT u.id FROM "users" AS "u" LEFT JOIN "phones" AS "p" ON p.user_id = u.id INNER JOIN "users" AS "m" ON m.id = u.manager_id`,
+			summary: "SELECT users phones",
+		},
+		{
+			title:   "Test Subquery And Union",
+			sql:     `SELECT id FROM (SELECT id FROM public.orders) AS o UNION SELECT id FROM invoices`,
+			summary: "SELECT public.orders invoices",
+		},
+		{
+			title:   "Test Where Subqueries",
+			sql:     `SELECT (SELECT count(*) FROM phones WHERE phones.user_id = u.id) FROM "users" AS "u" INNER JOIN "roles" AS "r" ON r.id = u.role_id AND r.id IN (SELECT role_id FROM grants) WHERE (u.id IN (SELECT user_id FROM orders) AND EXISTS (SELECT 1 FROM invoices WHERE invoices.user_id = u.id)) GROUP BY u.id HAVING count(*) > (SELECT 1 FROM limits)`,
+			summary: "SELECT phones users roles grants orders invoices limits",
+		},
+		{
+			title:   "Test Update Where Subquery",
+			sql:     `UPDATE "users" SET score = (SELECT max(score) FROM scores) WHERE id IN (SELECT user_id FROM sessions)`,
+			summary: "UPDATE users scores sessions",
+		},
+		{
+			title:   "Test Update",
+			sql:     `UPDATE "users" SET name = $1 WHERE (id = $2)`,
+			summary: "UPDATE users",
+		},
+		{
+			title:   "Test Cte Delete",
+			sql:     `WITH old AS (SELECT id FROM sessions) DELETE FROM tokens USING old WHERE tokens.session_id = old.id`,
+			summary: "DELETE sessions tokens",
+		},
+		{
+			title:   "Test Other Statements",
+			sql:     `TRUNCATE users; SELECT 1`,
+			summary: "TRUNCATE; SELECT",
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.title, func(t *testing.T) {
+			summary, err := Summarize(item.sql)
+
+			require.NoError(t, err)
+			assert.Equal(t, item.summary, summary)
+		})
+	}
+
+	_, err := Summarize("SELECT FROM WHERE")
+	assert.Error(t, err)
+}
+
+func TestFingerprint(t *testing.T) {
+	small, _ := NewQueryBuilder().From("users").WhereAnd(Where{Column: "id", Type: "in", Val: []int{1, 2}}).ToSelectSql()
+	large, _ := NewQueryBuilder().From("users").WhereAnd(Where{Column: "id", Type: "in", Val: []int{1, 2, 3, 4}}).ToSelectSql()
+	other, _ := NewQueryBuilder().From("users").WhereAnd(Where{Column: "name", Type: "in", Val: []int{1, 2}}).ToSelectSql()
+
+	fingerprintSmall, err := Fingerprint(small)
+	require.NoError(t, err)
+	fingerprintLarge, err := Fingerprint(large)
+	require.NoError(t, err)
+	fingerprintOther, err := Fingerprint(other)
+	require.NoError(t, err)
+
+	assert.Equal(t, fingerprintSmall, fingerprintLarge)
+	assert.NotEqual(t, fingerprintSmall, fingerprintOther)
+
+	t.Run("Validate Otel Span Attribute", func(t *testing.T) {
+		spanRecorder := tracetest.NewSpanRecorder()
+		tracer := trace.NewTracerProvider(trace.WithSpanProcessor(spanRecorder)).Tracer("test-tracer")
+
+		_, span := tracer.Start(context.Background(), "test-span")
+		NewQueryBuilder(SetOtelSpan(span)).From("users").WhereAnd(Where{Column: "id", Type: "in", Val: []int{1, 2, 3, 4}}).ToSelectSql()
+		span.End()
+
+		spans := spanRecorder.Ended()
+		require.Len(t, spans, 1)
+
+		attrs := spans[0].Attributes()
+		assert.Contains(t, attrs, attribute.String("db.query.summary", "SELECT users"))
+		assert.Contains(t, attrs, attribute.String("db.query.fingerprint", fingerprintSmall))
+	})
+}
@@ -0,0 +1,155 @@
+package pgxexec
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	query "github.com/MMortari/go-query-builder"
+	"github.com/MMortari/go-query-builder/executor"
+)
+
+// Batch agrupa as queries de vários QueryBuilders em um único pgx.Batch.
+//
+// Os resultados são lidos nos destinos informados ao enfileirar cada query, quando o batch é enviado com
+// Executor.SendBatch. O valor zero está pronto para uso.
+//
+// Exemplo de uso:
+//
+//	var (
+//	    batch pgxexec.Batch
+//	    users []User
+//	    total int64
+//	)
+//	batch.Select(qb, &users).Count(qb, &total).Exec(qbUpdate, nil)
+//
+//	err := exec.SendBatch(ctx, &batch)
+type Batch struct {
+	batch pgx.Batch
+	items []*batchItem
+	err   error
+}
+
+// batchItem guarda o builder de cada query enfileirada, utilizado para observá-la quando o batch é enviado.
+type batchItem struct {
+	qb          *query.QueryBuilder
+	stmt        query.Statement
+	observation *executor.Observation
+}
+
+// Select enfileira ToSelectSql, lendo as linhas no slice apontado por dest.
+func (b *Batch) Select(qb *query.QueryBuilder, dest any) *Batch {
+	if queued, item := b.queue(qb, qb.BuildSelect); queued != nil {
+		queued.Query(func(rows pgx.Rows) error {
+			counted := &countedRows{Rows: rows}
+			err := executor.ScanAll(Rows(counted), dest)
+			item.end(counted.count, err)
+			return err
+		})
+	}
+
+	return b
+}
+
+// Get enfileira ToSelectSql, lendo a primeira linha em dest com as mesmas regras de Executor.Get.
+func (b *Batch) Get(qb *query.QueryBuilder, dest ...any) *Batch {
+	queued, item := b.queue(qb, qb.BuildSelect)
+	if queued == nil {
+		return b
+	}
+
+	if len(dest) == 1 && executor.IsStructPointer(dest[0]) {
+		queued.Query(func(rows pgx.Rows) error {
+			counted := &countedRows{Rows: rows}
+			err := scanOne(counted, dest[0])
+			item.end(counted.count, err)
+			return err
+		})
+	} else {
+		queued.QueryRow(func(row pgx.Row) error {
+			err := row.Scan(dest...)
+			item.endRow(err)
+			return err
+		})
+	}
+
+	return b
+}
+
+// Count enfileira ToSelectTotalSql, lendo o total em total.
+func (b *Batch) Count(qb *query.QueryBuilder, total *int64) *Batch {
+	if queued, item := b.queue(qb, qb.BuildSelectTotal); queued != nil {
+		queued.QueryRow(func(row pgx.Row) error {
+			err := row.Scan(total)
+			item.endRow(err)
+			return err
+		})
+	}
+
+	return b
+}
+
+// Exec enfileira ToUpdateQuery. Quando tag não é nil, recebe o command tag retornado pelo banco.
+//
+// Assim como Executor.Exec, Executor.SendBatch retorna query.ErrStaleVersion quando a versão informada em
+// ExpectVersion não é mais a atual.
+func (b *Batch) Exec(qb *query.QueryBuilder, tag *pgconn.CommandTag) *Batch {
+	if queued, item := b.queue(qb, qb.BuildUpdate); queued != nil {
+		queued.Exec(func(ct pgconn.CommandTag) error {
+			item.endExec(ct.RowsAffected())
+			if tag != nil {
+				*tag = ct
+			}
+			return checkVersion(qb, ct)
+		})
+	}
+
+	return b
+}
+
+// Len retorna o número de queries enfileiradas.
+func (b *Batch) Len() int {
+	return b.batch.Len()
+}
+
+// queue renderiza a query com build e a adiciona ao batch, ou registra o erro da renderização para ser retornado
+// por Executor.SendBatch.
+func (b *Batch) queue(qb *query.QueryBuilder, build func() (query.Statement, error)) (*pgx.QueuedQuery, *batchItem) {
+	stmt, err := build()
+	if err != nil {
+		b.err = errors.Join(b.err, err)
+		return nil, nil
+	}
+
+	item := &batchItem{qb: qb, stmt: stmt}
+	b.items = append(b.items, item)
+
+	return b.batch.Queue(stmt.SQL, stmt.Args...), item
+}
+
+// end encerra a observação da query, quando iniciada por Executor.SendBatch e ainda não encerrada.
+func (i *batchItem) end(rows int64, err error) {
+	if i.observation == nil {
+		return
+	}
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		rows, err = 0, nil
+	}
+	i.observation.End(rows, err)
+	i.observation = nil
+}
+func (i *batchItem) endExec(affected int64) {
+	if i.observation != nil {
+		i.observation.EndExec(affected, nil)
+		i.observation = nil
+	}
+}
+func (i *batchItem) endRow(err error) {
+	if err == nil {
+		i.end(1, nil)
+		return
+	}
+	i.end(-1, err)
+}
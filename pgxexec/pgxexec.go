@@ -0,0 +1,263 @@
+package pgxexec
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	query "github.com/MMortari/go-query-builder"
+	"github.com/MMortari/go-query-builder/executor"
+)
+
+// Querier é o subconjunto da API do pgx utilizado pelo Executor.
+//
+// É satisfeito por *pgx.Conn, *pgxpool.Pool, pgx.Tx e por implementações em memória nos testes.
+type Querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+}
+
+type Executor struct {
+	db Querier
+	// observer cria os spans, métricas e eventos de Hook com as opções do pacote executor
+	observer *executor.Executor
+}
+
+// New cria um Executor que roda as queries geradas pelo QueryBuilder diretamente no pgx.
+//
+// As opções são as mesmas do pacote executor (executor.WithTracer, executor.WithMeter e executor.WithServer), e geram
+// os mesmos spans, métricas e eventos de Hook, inclusive para cada query enviada em um Batch.
+//
+// Exemplo de uso:
+//
+//	pool, _ := pgxpool.New(ctx, dsn)
+//	exec := pgxexec.New(pool, executor.WithTracer(otel.Tracer("users")), executor.WithMeter(otel.Meter("users")))
+func New(db Querier, opts ...executor.Option) *Executor {
+	return &Executor{db: db, observer: executor.New(nil, opts...)}
+}
+
+// Query executa ToSelectSql e retorna as linhas sem processamento.
+func (e *Executor) Query(ctx context.Context, qb *query.QueryBuilder) (pgx.Rows, error) {
+	rows, o, err := e.query(ctx, qb)
+	if err != nil {
+		return nil, err
+	}
+
+	// As linhas são lidas por quem chamou, então a observação é encerrada sem o número de linhas
+	o.End(-1, nil)
+
+	return rows.Rows, nil
+}
+
+// Select executa ToSelectSql e adiciona cada linha retornada ao slice apontado por dest, com as mesmas regras
+// de mapeamento de executor.ScanAll.
+func (e *Executor) Select(ctx context.Context, qb *query.QueryBuilder, dest any) error {
+	rows, o, err := e.query(ctx, qb)
+	if err != nil {
+		return err
+	}
+
+	return rows.close(o, executor.ScanAll(Rows(rows), dest))
+}
+
+// Get executa ToSelectSql e lê a primeira linha retornada em dest.
+//
+// Quando dest é um único ponteiro para struct, as colunas são mapeadas pela tag `db`. Caso contrário, dest é lido
+// da mesma forma que pgx.Row.Scan. Retorna pgx.ErrNoRows quando a query não retorna nenhuma linha.
+func (e *Executor) Get(ctx context.Context, qb *query.QueryBuilder, dest ...any) error {
+	if len(dest) == 1 && executor.IsStructPointer(dest[0]) {
+		rows, o, err := e.query(ctx, qb)
+		if err != nil {
+			return err
+		}
+
+		return rows.close(o, scanOne(rows, dest[0]))
+	}
+
+	stmt, err := qb.BuildSelect()
+	if err != nil {
+		return err
+	}
+
+	return e.queryRow(ctx, qb, stmt, dest...)
+}
+
+// Count executa ToSelectTotalSql e retorna o total de registros.
+func (e *Executor) Count(ctx context.Context, qb *query.QueryBuilder) (total int64, err error) {
+	stmt, err := qb.BuildSelectTotal()
+	if err != nil {
+		return 0, err
+	}
+
+	err = e.queryRow(ctx, qb, stmt, &total)
+
+	return total, err
+}
+
+// Exec executa ToUpdateQuery e retorna o command tag do banco.
+//
+// Com query.OptimisticLock e a versão informada em ExpectVersion, retorna query.ErrStaleVersion quando nenhum
+// registro é alterado.
+func (e *Executor) Exec(ctx context.Context, qb *query.QueryBuilder) (pgconn.CommandTag, error) {
+	stmt, err := qb.BuildUpdate()
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+
+	return e.exec(ctx, qb, stmt)
+}
+
+// Delete executa ToDeleteQuery e retorna o command tag do banco. Quando a tabela foi configurada com
+// query.SoftDelete, a query executada é o UPDATE de remoção lógica. Assim como Exec, retorna query.ErrStaleVersion
+// quando a versão informada em ExpectVersion não é mais a atual.
+func (e *Executor) Delete(ctx context.Context, qb *query.QueryBuilder) (pgconn.CommandTag, error) {
+	stmt, err := qb.BuildDelete()
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+
+	return e.exec(ctx, qb, stmt)
+}
+
+// query, queryRow e exec são os únicos pontos que executam SQL no banco, todos observados por e.observer. As linhas
+// retornadas por query precisam ser fechadas com close, que também encerra a observação.
+func (e *Executor) query(ctx context.Context, qb *query.QueryBuilder) (*countedRows, *executor.Observation, error) {
+	stmt, err := qb.BuildSelect()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, o := e.observer.Observe(ctx, qb, stmt)
+
+	rows, err := e.db.Query(ctx, stmt.SQL, stmt.Args...)
+	if err != nil {
+		o.End(-1, err)
+		return nil, nil, err
+	}
+
+	return &countedRows{Rows: rows}, o, nil
+}
+func (e *Executor) queryRow(ctx context.Context, qb *query.QueryBuilder, stmt query.Statement, dest ...any) error {
+	ctx, o := e.observer.Observe(ctx, qb, stmt)
+
+	err := e.db.QueryRow(ctx, stmt.SQL, stmt.Args...).Scan(dest...)
+	endRow(o, err)
+
+	return err
+}
+func (e *Executor) exec(ctx context.Context, qb *query.QueryBuilder, stmt query.Statement) (pgconn.CommandTag, error) {
+	ctx, o := e.observer.Observe(ctx, qb, stmt)
+
+	tag, err := e.db.Exec(ctx, stmt.SQL, stmt.Args...)
+	if err != nil {
+		o.EndExec(-1, err)
+		return tag, err
+	}
+	o.EndExec(tag.RowsAffected(), nil)
+
+	return tag, checkVersion(qb, tag)
+}
+
+// SendBatch envia todas as queries enfileiradas em b em um único round trip e preenche os destinos informados
+// em cada chamada de b.
+func (e *Executor) SendBatch(ctx context.Context, b *Batch) error {
+	if b.err != nil {
+		return b.err
+	}
+
+	for _, item := range b.items {
+		_, item.observation = e.observer.Observe(ctx, item.qb, item.stmt)
+	}
+
+	err := e.db.SendBatch(ctx, &b.batch).Close()
+
+	// O pgx interrompe o batch no primeiro erro, então as queries seguintes não chegam a ser lidas
+	for _, item := range b.items {
+		item.end(-1, err)
+	}
+
+	return err
+}
+
+// Rows adapta pgx.Rows para a interface executor.Rows, permitindo utilizar executor.ScanAll e executor.ScanOne.
+func Rows(rows pgx.Rows) executor.Rows {
+	return pgxRows{rows}
+}
+
+type pgxRows struct {
+	pgx.Rows
+}
+
+func (r pgxRows) Columns() ([]string, error) {
+	fields := r.FieldDescriptions()
+
+	columns := make([]string, 0, len(fields))
+	for _, item := range fields {
+		columns = append(columns, item.Name)
+	}
+
+	return columns, nil
+}
+
+func scanOne(rows pgx.Rows, dest any) error {
+	err := executor.ScanOne(Rows(rows), dest)
+	if errors.Is(err, sql.ErrNoRows) {
+		return pgx.ErrNoRows
+	}
+
+	return err
+}
+
+// countedRows conta as linhas lidas para registrar db.response.returned_rows ao encerrar a observação.
+type countedRows struct {
+	pgx.Rows
+	count int64
+}
+
+func (r *countedRows) Next() bool {
+	if !r.Rows.Next() {
+		return false
+	}
+
+	r.count++
+	return true
+}
+
+// close fecha as linhas e encerra a observação com o erro do processamento, retornando o mesmo erro.
+func (r *countedRows) close(o *executor.Observation, err error) error {
+	r.Rows.Close()
+	if errors.Is(err, pgx.ErrNoRows) {
+		o.End(0, nil)
+	} else {
+		o.End(r.count, err)
+	}
+
+	return err
+}
+
+// endRow encerra a observação de uma query lida com QueryRow. Assim como sql.ErrNoRows no pacote executor,
+// pgx.ErrNoRows não é considerado um erro.
+func endRow(o *executor.Observation, err error) {
+	switch {
+	case err == nil:
+		o.End(1, nil)
+	case errors.Is(err, pgx.ErrNoRows):
+		o.End(0, nil)
+	default:
+		o.End(-1, err)
+	}
+}
+
+// checkVersion retorna query.ErrStaleVersion quando o builder possui versão esperada e nenhum registro foi alterado.
+func checkVersion(qb *query.QueryBuilder, tag pgconn.CommandTag) error {
+	if version, ok := qb.ExpectedVersion(); ok && tag.RowsAffected() == 0 {
+		return executor.StaleVersion(qb, version)
+	}
+
+	return nil
+}
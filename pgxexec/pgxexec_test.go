@@ -0,0 +1,357 @@
+package pgxexec
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	query "github.com/MMortari/go-query-builder"
+	"github.com/MMortari/go-query-builder/executor"
+)
+
+// fakeDB é uma implementação em memória de Querier, que devolve os resultados na ordem em que foram cadastrados.
+type fakeDB struct {
+	results []fakeResult
+	queries []fakeQuery
+}
+type fakeResult struct {
+	columns []string
+	rows    [][]any
+	tag     pgconn.CommandTag
+	err     error
+}
+type fakeQuery struct {
+	sql  string
+	args []any
+}
+
+func (f *fakeDB) next(sql string, args []any) fakeResult {
+	f.queries = append(f.queries, fakeQuery{sql: sql, args: args})
+
+	if len(f.results) == 0 {
+		return fakeResult{err: errors.New("fake: unexpected query " + sql)}
+	}
+
+	result := f.results[0]
+	f.results = f.results[1:]
+
+	return result
+}
+func (f *fakeDB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	result := f.next(sql, args)
+	return result.tag, result.err
+}
+func (f *fakeDB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	result := f.next(sql, args)
+	return &fakeRows{result: result, index: -1}, result.err
+}
+func (f *fakeDB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return fakeRow{rows: &fakeRows{result: f.next(sql, args), index: -1}}
+}
+func (f *fakeDB) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	return &fakeBatchResults{db: f, batch: b}
+}
+
+type fakeRows struct {
+	result fakeResult
+	index  int
+}
+
+func (r *fakeRows) Close()                        {}
+func (r *fakeRows) Err() error                    { return r.result.err }
+func (r *fakeRows) CommandTag() pgconn.CommandTag { return r.result.tag }
+func (r *fakeRows) FieldDescriptions() []pgconn.FieldDescription {
+	fields := make([]pgconn.FieldDescription, 0, len(r.result.columns))
+	for _, item := range r.result.columns {
+		fields = append(fields, pgconn.FieldDescription{Name: item})
+	}
+	return fields
+}
+func (r *fakeRows) Next() bool {
+	if r.result.err != nil {
+		return false
+	}
+	r.index++
+	return r.index < len(r.result.rows)
+}
+func (r *fakeRows) Scan(dest ...any) error {
+	row := r.result.rows[r.index]
+	if len(dest) != len(row) {
+		return errors.New("fake: wrong number of scan destinations")
+	}
+
+	for i, item := range dest {
+		target := reflect.ValueOf(item).Elem()
+		if row[i] == nil {
+			target.Set(reflect.Zero(target.Type()))
+			continue
+		}
+
+		value := reflect.ValueOf(row[i])
+		if target.Kind() == reflect.Pointer {
+			target.Set(reflect.New(target.Type().Elem()))
+			target = target.Elem()
+		}
+		target.Set(value.Convert(target.Type()))
+	}
+
+	return nil
+}
+func (r *fakeRows) Values() ([]any, error) { return r.result.rows[r.index], nil }
+func (r *fakeRows) RawValues() [][]byte    { return nil }
+func (r *fakeRows) Conn() *pgx.Conn        { return nil }
+
+type fakeRow struct {
+	rows *fakeRows
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if !r.rows.Next() {
+		if r.rows.Err() != nil {
+			return r.rows.Err()
+		}
+		return pgx.ErrNoRows
+	}
+	return r.rows.Scan(dest...)
+}
+
+type fakeBatchResults struct {
+	db    *fakeDB
+	batch *pgx.Batch
+	index int
+}
+
+func (b *fakeBatchResults) next() fakeResult {
+	item := b.batch.QueuedQueries[b.index]
+	b.index++
+	return b.db.next(item.SQL, item.Arguments)
+}
+func (b *fakeBatchResults) Exec() (pgconn.CommandTag, error) {
+	result := b.next()
+	return result.tag, result.err
+}
+func (b *fakeBatchResults) Query() (pgx.Rows, error) {
+	result := b.next()
+	return &fakeRows{result: result, index: -1}, result.err
+}
+func (b *fakeBatchResults) QueryRow() pgx.Row {
+	return fakeRow{rows: &fakeRows{result: b.next(), index: -1}}
+}
+func (b *fakeBatchResults) Close() error {
+	var errs []error
+	for b.index < len(b.batch.QueuedQueries) {
+		errs = append(errs, b.batch.QueuedQueries[b.index].Fn(b))
+	}
+	return errors.Join(errs...)
+}
+
+type pgxPhone struct {
+	Phone string `db:"phone"`
+}
+type pgxUser struct {
+	ID    int64    `db:"id"`
+	Name  *string  `db:"name"`
+	Phone pgxPhone `db:"p"`
+}
+
+func TestPgxExecutor(t *testing.T) {
+	ctx := context.Background()
+	qbUsers := func() *query.QueryBuilder {
+		return query.NewQueryBuilder().From("users", "u").
+			Select("u.id", "u.name", `p.phone AS "p.phone"`).
+			Join(query.Join{Table: "phones", As: "p", On: "p.user_id = u.id"}).
+			WhereAnd(query.Where{Column: "u.active", Type: "=", Val: true})
+	}
+
+	t.Run("Test Select", func(t *testing.T) {
+		db := &fakeDB{results: []fakeResult{
+			{columns: []string{"id", "name", "p.phone"}, rows: [][]any{{1, "Mark", "5511999999999"}, {2, nil, "5511888888888"}}},
+		}}
+
+		var users []pgxUser
+		err := New(db).Select(ctx, qbUsers(), &users)
+		require.NoError(t, err)
+
+		require.Len(t, users, 2)
+		assert.Equal(t, int64(1), users[0].ID)
+		assert.Equal(t, "Mark", *users[0].Name)
+		assert.Equal(t, "5511999999999", users[0].Phone.Phone)
+		assert.Nil(t, users[1].Name)
+
+		assert.Equal(t, []fakeQuery{{
+			sql:  `SELECT u.id, u.name, p.phone AS "p.phone" FROM "users" AS "u" INNER JOIN "phones" AS "p" ON p.user_id = u.id WHERE (u.active = $1)`,
+			args: []any{true},
+		}}, db.queries)
+	})
+
+	t.Run("Test Get", func(t *testing.T) {
+		db := &fakeDB{results: []fakeResult{
+			{columns: []string{"id", "name", "p.phone"}, rows: [][]any{{1, "Mark", "5511999999999"}}},
+			{columns: []string{"id"}, rows: [][]any{{1}}},
+			{columns: []string{"id"}},
+		}}
+
+		var user pgxUser
+		require.NoError(t, New(db).Get(ctx, qbUsers(), &user))
+		assert.Equal(t, int64(1), user.ID)
+
+		var id int64
+		require.NoError(t, New(db).Get(ctx, qbUsers(), &id))
+		assert.Equal(t, int64(1), id)
+
+		err := New(db).Get(ctx, qbUsers(), &user)
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("Test Count And Exec", func(t *testing.T) {
+		db := &fakeDB{results: []fakeResult{
+			{columns: []string{"total"}, rows: [][]any{{42}}},
+			{tag: pgconn.NewCommandTag("UPDATE 3")},
+			{tag: pgconn.NewCommandTag("DELETE 1")},
+		}}
+
+		total, err := New(db).Count(ctx, qbUsers())
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), total)
+
+		tag, err := New(db).Exec(ctx, query.NewQueryBuilder().From("users").Values(query.Value{Column: "active", Val: false}))
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), tag.RowsAffected())
+
+		tag, err = New(db).Delete(ctx, query.NewQueryBuilder().From("users").WhereAnd(query.Where{Column: "id", Type: "=", Val: 1}))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), tag.RowsAffected())
+
+		assert.Equal(t, `SELECT COUNT(*) AS total FROM "users" AS "u" INNER JOIN "phones" AS "p" ON p.user_id = u.id WHERE (u.active = $1)`, db.queries[0].sql)
+		assert.Equal(t, `UPDATE "users" SET active = $1`, db.queries[1].sql)
+		assert.Equal(t, `DELETE FROM "users" WHERE (id = $1)`, db.queries[2].sql)
+	})
+
+	t.Run("Test Send Batch", func(t *testing.T) {
+		db := &fakeDB{results: []fakeResult{
+			{columns: []string{"id", "name", "p.phone"}, rows: [][]any{{1, "Mark", "5511999999999"}}},
+			{columns: []string{"total"}, rows: [][]any{{1}}},
+			{columns: []string{"id", "name", "p.phone"}, rows: [][]any{{1, "Mark", "5511999999999"}}},
+			{tag: pgconn.NewCommandTag("UPDATE 1")},
+		}}
+
+		var (
+			batch Batch
+			users []pgxUser
+			user  pgxUser
+			total int64
+			tag   pgconn.CommandTag
+		)
+		batch.
+			Select(qbUsers(), &users).
+			Count(qbUsers(), &total).
+			Get(qbUsers(), &user).
+			Exec(query.NewQueryBuilder().From("users").Values(query.Value{Column: "name", Val: "Mark"}).WhereAnd(query.Where{Column: "id", Type: "=", Val: 1}), &tag)
+
+		assert.Equal(t, 4, batch.Len())
+		require.NoError(t, New(db).SendBatch(ctx, &batch))
+
+		assert.Len(t, users, 1)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, int64(1), user.ID)
+		assert.Equal(t, int64(1), tag.RowsAffected())
+		assert.Equal(t, fakeQuery{sql: `UPDATE "users" SET name = $1 WHERE (id = $2)`, args: []any{"Mark", 1}}, db.queries[3])
+	})
+
+	t.Run("Test Stale Version", func(t *testing.T) {
+		db := &fakeDB{results: []fakeResult{
+			{tag: pgconn.NewCommandTag("UPDATE 0")},
+			{tag: pgconn.NewCommandTag("UPDATE 1")},
+			{tag: pgconn.NewCommandTag("UPDATE 0")},
+		}}
+
+		qb := func() *query.QueryBuilder {
+			return query.NewQueryBuilder(query.OptimisticLock("version")).From("users").Values(query.Value{Column: "name", Val: "Mark"}).ExpectVersion(3)
+		}
+
+		_, err := New(db).Exec(ctx, qb())
+		assert.ErrorIs(t, err, query.ErrStaleVersion)
+
+		_, err = New(db).Exec(ctx, qb())
+		assert.NoError(t, err)
+
+		var batch Batch
+		batch.Exec(qb(), nil)
+		assert.ErrorIs(t, New(db).SendBatch(ctx, &batch), query.ErrStaleVersion)
+
+		assert.Equal(t, `UPDATE "users" SET name = $1, version = version + 1 WHERE "users".version = $2`, db.queries[0].sql)
+	})
+
+	t.Run("Test Tracer And Hook", func(t *testing.T) {
+		db := &fakeDB{results: []fakeResult{
+			{columns: []string{"id", "name", "p.phone"}, rows: [][]any{{1, "Mark", "5511999999999"}, {2, nil, "5511888888888"}}},
+			{columns: []string{"total"}, rows: [][]any{{2}}},
+			{tag: pgconn.NewCommandTag("UPDATE 1")},
+		}}
+
+		recorder := tracetest.NewSpanRecorder()
+		tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+		exec := New(db, executor.WithTracer(tracer), executor.WithServer("db.internal", 5432))
+
+		var events []query.Event
+		qb := query.NewQueryBuilder(query.SetHook(query.HookFunc(func(ctx context.Context, event query.Event) {
+			if event.Kind == query.EventExecute {
+				events = append(events, event)
+			}
+		}))).From("users", "u").Select("u.id", "u.name", `p.phone AS "p.phone"`).
+			Join(query.Join{Table: "phones", As: "p", On: "p.user_id = u.id"}).
+			WhereAnd(query.Where{Column: "u.active", Type: "=", Val: true})
+
+		var users []pgxUser
+		require.NoError(t, exec.Select(ctx, qb, &users))
+
+		var (
+			batch Batch
+			total int64
+		)
+		batch.Count(qb, &total).Exec(query.NewQueryBuilder().From("users").Values(query.Value{Column: "name", Val: "Mark"}), nil)
+		require.NoError(t, exec.SendBatch(ctx, &batch))
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 3)
+
+		attrs := make(map[attribute.Key]attribute.Value)
+		for _, item := range spans[0].Attributes() {
+			attrs[item.Key] = item.Value
+		}
+		assert.Equal(t, "SELECT users", spans[0].Name())
+		assert.Equal(t, "postgresql", attrs["db.system.name"].AsString())
+		assert.Equal(t, "db.internal", attrs["server.address"].AsString())
+		assert.Equal(t, int64(2), attrs["db.response.returned_rows"].AsInt64())
+
+		assert.Equal(t, "SELECT users", spans[1].Name())
+		assert.Equal(t, "UPDATE users", spans[2].Name())
+		assert.Contains(t, spans[2].Attributes(), attribute.Int64("db.response.affected_rows", 1))
+
+		require.Len(t, events, 2)
+		assert.Equal(t, "SELECT", events[0].Operation)
+		assert.Equal(t, []any{true}, events[0].Args)
+		assert.Equal(t, "SELECT", events[1].Operation)
+	})
+
+	t.Run("Test Send Batch Builder Error", func(t *testing.T) {
+		db := &fakeDB{}
+
+		var batch Batch
+		batch.Count(query.NewQueryBuilder(query.EmptyIn(query.EmptyInError)).From("users").WhereAnd(query.Where{Column: "id", Type: "in", Val: []int{}}), new(int64))
+
+		assert.ErrorIs(t, New(db).SendBatch(ctx, &batch), query.ErrEmptyIn)
+		assert.Empty(t, db.queries)
+	})
+}
@@ -0,0 +1,169 @@
+package query
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+)
+
+// RedactedValue é o valor registrado no span para parâmetros redigidos quando RedactionPolicy.Redactor não é informado.
+const RedactedValue = "[REDACTED]"
+
+// Redactor transforma o valor de um parâmetro antes dele ser registrado no span.
+type Redactor func(column string, value string) string
+
+// RedactionPolicy define quais valores de parâmetros são registrados nos atributos `db.query.parameter.<col>`.
+//
+// Com a política ativa, cada coluna é tratada na seguinte ordem:
+//
+//   - Deny: o atributo não é registrado.
+//   - Allow: o valor é registrado sem alteração.
+//   - demais colunas: o valor é registrado após passar pelo Redactor.
+//
+// Com Allow vazio, todas as colunas fora de Deny são redigidas. As colunas podem ser informadas com ou sem o alias
+// da tabela: "email" corresponde a "email" e "u.email", enquanto "u.email" corresponde somente a "u.email".
+type RedactionPolicy struct {
+	Allow []string
+	Deny  []string
+	// Redactor transforma os valores das colunas que não estão em Allow. Quando nil, RedactedValue é registrado.
+	Redactor Redactor
+	// MaxSliceLen limita o número de valores registrados para slices (ex: IN). Quando excedido, os primeiros valores
+	// são registrados e o tamanho original fica em `db.query.parameter.<col>.length`. Quando zero, não há limite.
+	MaxSliceLen int
+}
+
+// Redact ativa a política de redação dos parâmetros registrados no span configurado com SetOtelSpan.
+//
+// Com ParseWhere(false), os valores inseridos no SQL também são removidos da query registrada no span e enviada ao
+// Hook, substituídos por placeholders.
+//
+// Exemplo de uso:
+//
+//	qb := query.NewQueryBuilder(
+//	    query.SetOtelSpan(span),
+//	    query.Redact(query.RedactionPolicy{
+//	        Allow:       []string{"status", "age"},
+//	        Deny:        []string{"password"},
+//	        Redactor:    query.Mask(2),
+//	        MaxSliceLen: 20,
+//	    }),
+//	)
+func Redact(policy RedactionPolicy) QueryBuilderConfig {
+	return func(q *QueryBuilder) {
+		q.redaction = &policy
+	}
+}
+
+// Mask substitui os caracteres do valor por `*`, mantendo visíveis os últimos `visible` caracteres.
+//
+// Ex: Mask(2) registra o CPF "123.456.789-10" como "************10".
+func Mask(visible int) Redactor {
+	return func(column string, value string) string {
+		runes := []rune(value)
+
+		hidden := len(runes) - visible
+		if hidden < 0 {
+			hidden = 0
+		}
+
+		return strings.Repeat("*", hidden) + string(runes[hidden:])
+	}
+}
+
+// Hash substitui o valor pelo início do seu HMAC-SHA256, permitindo correlacionar o mesmo valor entre spans sem
+// expô-lo. A chave impede que valores com poucas combinações, como CPFs, sejam descobertos por força bruta.
+//
+// O valor registrado tem o formato "hmac:" seguido de 16 caracteres hexadecimais.
+func Hash(key []byte) Redactor {
+	return func(column string, value string) string {
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(value))
+
+		return "hmac:" + hex.EncodeToString(mac.Sum(nil))[:16]
+	}
+}
+
+// redact aplica a política de redação no valor da coluna. Retorna false quando o valor não deve ser registrado.
+func (p *RedactionPolicy) redact(column string, value string) (string, bool) {
+	if p == nil {
+		return value, true
+	}
+
+	if matchColumn(p.Deny, column) {
+		return "", false
+	}
+	if matchColumn(p.Allow, column) {
+		return value, true
+	}
+	if p.Redactor == nil {
+		return RedactedValue, true
+	}
+
+	return p.Redactor(column, value), true
+}
+
+func matchColumn(columns []string, column string) bool {
+	if slices.Contains(columns, column) {
+		return true
+	}
+
+	if i := strings.LastIndexByte(column, '.'); i != -1 {
+		return slices.Contains(columns, column[i+1:])
+	}
+
+	return false
+}
+
+// queryText retorna a query registrada no span e enviada ao Hook. Com ParseWhere(false) os valores são inseridos no
+// SQL, fora do alcance da redação dos parâmetros; com uma política ativa, eles são substituídos por placeholders com
+// pg_query.Normalize. Quando a query não pode ser analisada, retorna vazio para que nenhum valor seja exposto.
+func (q *QueryBuilder) queryText(query string) string {
+	if q.redaction == nil || q.config.parseWhere {
+		return query
+	}
+
+	normalized, err := pg_query.Normalize(query)
+	if err != nil {
+		return ""
+	}
+
+	return normalized
+}
+
+// setSpanText registra a query no span, ignorando queries omitidas por queryText.
+func (q *QueryBuilder) setSpanText(key, text string) {
+	if text != "" {
+		q.setSpanAttribute(key, text)
+	}
+}
+
+func (q *QueryBuilder) setSpanParameter(column string, val any) {
+	if q.otelSpan == nil {
+		return
+	}
+
+	if value, ok := q.redaction.redact(column, fmt.Sprint(val)); ok {
+		q.setSpanAttribute("db.query.parameter."+column, value)
+	}
+}
+func (q *QueryBuilder) setSpanParameterSlice(column string, val []any) {
+	if q.otelSpan == nil {
+		return
+	}
+
+	values := make([]string, 0, len(val))
+	for _, item := range val {
+		value, ok := q.redaction.redact(column, fmt.Sprint(item))
+		if !ok {
+			return
+		}
+		values = append(values, value)
+	}
+
+	q.setSpanAttributeSlice("db.query.parameter."+column, values)
+}
@@ -0,0 +1,100 @@
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRedact(t *testing.T) {
+	spanAttributes := func(t *testing.T, configs ...QueryBuilderConfig) map[attribute.Key]attribute.Value {
+		spanRecorder := tracetest.NewSpanRecorder()
+		tracer := trace.NewTracerProvider(trace.WithSpanProcessor(spanRecorder)).Tracer("test-tracer")
+
+		_, span := tracer.Start(context.Background(), "test-span")
+
+		NewQueryBuilder(append(configs, SetOtelSpan(span))...).From("users", "u").WhereAnd(
+			Where{Column: "u.email", Type: "=", Val: "john@doe.com"},
+			Where{Column: "u.cpf", Type: "=", Val: "123.456.789-10"},
+			Where{Column: "u.password", Type: "=", Val: "secret"},
+			Where{Column: "u.status", Type: "in", Val: []string{"a", "b", "c", "d"}},
+			Where{Column: "u.age", Type: ">", Val: 18},
+		).ToSelectSql()
+		span.End()
+
+		spans := spanRecorder.Ended()
+		require.Len(t, spans, 1)
+
+		attrs := make(map[attribute.Key]attribute.Value)
+		for _, item := range spans[0].Attributes() {
+			attrs[item.Key] = item.Value
+		}
+		return attrs
+	}
+
+	t.Run("Validate Without Policy", func(t *testing.T) {
+		attrs := spanAttributes(t)
+
+		assert.Equal(t, "john@doe.com", attrs["db.query.parameter.u.email"].AsString())
+		assert.Equal(t, "secret", attrs["db.query.parameter.u.password"].AsString())
+		assert.Equal(t, []string{"a", "b", "c", "d"}, attrs["db.query.parameter.u.status"].AsStringSlice())
+	})
+
+	t.Run("Validate Default Redactor", func(t *testing.T) {
+		attrs := spanAttributes(t, Redact(RedactionPolicy{}))
+
+		assert.Equal(t, RedactedValue, attrs["db.query.parameter.u.email"].AsString())
+		assert.Equal(t, RedactedValue, attrs["db.query.parameter.u.age"].AsString())
+		assert.Equal(t, []string{RedactedValue, RedactedValue, RedactedValue, RedactedValue}, attrs["db.query.parameter.u.status"].AsStringSlice())
+	})
+
+	t.Run("Validate Allow Deny And Slice Cap", func(t *testing.T) {
+		attrs := spanAttributes(t, Redact(RedactionPolicy{
+			Allow:       []string{"status", "u.age", "password"},
+			Deny:        []string{"u.password"},
+			Redactor:    Mask(2),
+			MaxSliceLen: 2,
+		}))
+
+		assert.Equal(t, "**********om", attrs["db.query.parameter.u.email"].AsString())
+		assert.Equal(t, "************10", attrs["db.query.parameter.u.cpf"].AsString())
+		assert.Equal(t, "18", attrs["db.query.parameter.u.age"].AsString())
+		assert.NotContains(t, attrs, attribute.Key("db.query.parameter.u.password"))
+		assert.Equal(t, []string{"a", "b"}, attrs["db.query.parameter.u.status"].AsStringSlice())
+		assert.Equal(t, int64(4), attrs["db.query.parameter.u.status.length"].AsInt64())
+	})
+
+	t.Run("Validate Inline Values", func(t *testing.T) {
+		attrs := spanAttributes(t, ParseWhere(false))
+		assert.Contains(t, attrs["db.query.text"].AsString(), `u.email = 'john@doe.com'`)
+
+		var events []Event
+		attrs = spanAttributes(t, ParseWhere(false), Redact(RedactionPolicy{}), SetHook(HookFunc(func(ctx context.Context, event Event) {
+			events = append(events, event)
+		})))
+
+		text := `SELECT * FROM "users" AS "u" WHERE (u.email = $1 AND u.cpf = $2 AND u.password = $3 AND u.status IN ($4, $5, $6, $7) AND u.age > $8)`
+		assert.Equal(t, text, attrs["db.query.text"].AsString())
+		require.Len(t, events, 1)
+		assert.Equal(t, text, events[0].SQL)
+	})
+
+	t.Run("Validate Hash", func(t *testing.T) {
+		hash := Hash([]byte("key"))
+
+		assert.Equal(t, hash("email", "john@doe.com"), hash("cpf", "john@doe.com"))
+		assert.NotEqual(t, hash("email", "john@doe.com"), Hash([]byte("other"))("email", "john@doe.com"))
+		assert.Regexp(t, `^hmac:[0-9a-f]{16}$`, hash("email", "john@doe.com"))
+	})
+
+	t.Run("Validate Mask", func(t *testing.T) {
+		assert.Equal(t, "****", Mask(0)("name", "Mark"))
+		assert.Equal(t, "Mark", Mask(10)("name", "Mark"))
+		assert.Equal(t, "**ão", Mask(2)("name", "João"))
+	})
+}
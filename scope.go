@@ -0,0 +1,66 @@
+package query
+
+// Scope é um fragmento reutilizável de query, como "ativos e não removidos" ou "visíveis ao usuário".
+//
+// Um Scope deve retornar o builder recebido pelos métodos encadeados, o que o mantém compatível com o modo Immutable.
+//
+// Exemplo de uso:
+//
+//	func Active(qb *query.QueryBuilder) *query.QueryBuilder {
+//	    return qb.WhereAnd(query.Where{Column: "active", Type: "=", Val: true})
+//	}
+//
+//	func Tenant(id int) query.Scope {
+//	    return func(qb *query.QueryBuilder) *query.QueryBuilder {
+//	        return qb.WhereAnd(query.Where{Column: "tenant_id", Type: "=", Val: id})
+//	    }
+//	}
+type Scope func(*QueryBuilder) *QueryBuilder
+
+// Apply aplica o scope no builder. Um scope nil é ignorado.
+//
+// Exemplo de uso:
+//
+//	qb := query.NewQueryBuilder().From("users").Apply(Active)
+func (q *QueryBuilder) Apply(scope Scope) *QueryBuilder {
+	if scope == nil {
+		return q
+	}
+
+	return scope(q)
+}
+
+// Scopes aplica os scopes no builder, na ordem informada.
+//
+// Exemplo de uso:
+//
+//	qb := query.NewQueryBuilder().From("users").Scopes(Active, Tenant(tenantID))
+func (q *QueryBuilder) Scopes(scopes ...Scope) *QueryBuilder {
+	for _, item := range scopes {
+		q = q.Apply(item)
+	}
+
+	return q
+}
+
+// When aplica o scope somente quando cond é verdadeiro, permitindo filtros opcionais sem interromper o encadeamento.
+//
+// Exemplo de uso:
+//
+//	qb := query.NewQueryBuilder().From("users").
+//	    When(name != "", func(qb *query.QueryBuilder) *query.QueryBuilder {
+//	        return qb.WhereAnd(query.Where{Column: "name", Type: "ILIKE", Val: "%" + name + "%"})
+//	    }).
+//	    OrderBy(query.OrderBy{Column: "name"})
+func (q *QueryBuilder) When(cond bool, scope Scope) *QueryBuilder {
+	if !cond {
+		return q
+	}
+
+	return q.Apply(scope)
+}
+
+// Unless aplica o scope somente quando cond é falso.
+func (q *QueryBuilder) Unless(cond bool, scope Scope) *QueryBuilder {
+	return q.When(!cond, scope)
+}
@@ -0,0 +1,72 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScopes(t *testing.T) {
+	active := func(qb *QueryBuilder) *QueryBuilder {
+		return qb.WhereAnd(Where{Column: "active", Type: "=", Val: true}, Where{Column: "deleted_at", Type: "IS NULL"})
+	}
+	tenant := func(id int) Scope {
+		return func(qb *QueryBuilder) *QueryBuilder {
+			return qb.WhereAnd(Where{Column: "tenant_id", Type: "=", Val: id})
+		}
+	}
+
+	data := []struct {
+		title       string
+		data        *QueryBuilder
+		resultQuery string
+		resultData  []interface{}
+	}{
+		{
+			title:       "Test Apply",
+			data:        NewQueryBuilder().From("users").Apply(active).Apply(nil),
+			resultQuery: `SELECT * FROM "users" WHERE (active = $1 AND deleted_at IS NULL)`,
+			resultData:  []interface{}{true},
+		},
+		{
+			title:       "Test Scopes",
+			data:        NewQueryBuilder().From("users").Scopes(active, tenant(7)).OrderBy(OrderBy{Column: "name"}),
+			resultQuery: `SELECT * FROM "users" WHERE (active = $1 AND deleted_at IS NULL) AND (tenant_id = $2) ORDER BY name`,
+			resultData:  []interface{}{true, 7},
+		},
+		{
+			title:       "Test When",
+			data:        NewQueryBuilder().From("users").When(true, active).When(false, tenant(7)).Limit(10),
+			resultQuery: `SELECT * FROM "users" WHERE (active = $1 AND deleted_at IS NULL) LIMIT 10`,
+			resultData:  []interface{}{true},
+		},
+		{
+			title:       "Test Unless",
+			data:        NewQueryBuilder().From("users").Unless(true, active).Unless(false, tenant(7)),
+			resultQuery: `SELECT * FROM "users" WHERE (tenant_id = $1)`,
+			resultData:  []interface{}{7},
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.title, func(t *testing.T) {
+			query, queryData := item.data.ToSelectSql()
+
+			assert.Equal(t, item.resultQuery, query)
+			assert.Equal(t, item.resultData, queryData)
+		})
+	}
+
+	t.Run("Validate Immutable", func(t *testing.T) {
+		base := NewQueryBuilder(Immutable()).From("users")
+
+		scoped := base.Scopes(active, tenant(7)).When(true, tenant(8))
+
+		sql, _ := base.ToSelectSql()
+		assert.Equal(t, `SELECT * FROM "users"`, sql)
+
+		sql, params := scoped.ToSelectSql()
+		assert.Equal(t, `SELECT * FROM "users" WHERE (active = $1 AND deleted_at IS NULL) AND (tenant_id = $2) AND (tenant_id = $3)`, sql)
+		assert.Equal(t, []interface{}{true, 7, 8}, params)
+	})
+}
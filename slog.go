@@ -0,0 +1,108 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type SlogOption func(*slogHook)
+
+type slogHook struct {
+	logger        *slog.Logger
+	level         slog.Level
+	errorLevel    slog.Level
+	slowLevel     slog.Level
+	slowThreshold time.Duration
+	builds        bool
+}
+
+// SlogLevel define o nível dos eventos sem erro e dentro do limite de SlogSlowQuery. O padrão é slog.LevelDebug.
+func SlogLevel(level slog.Level) SlogOption {
+	return func(h *slogHook) {
+		h.level = level
+	}
+}
+
+// SlogErrorLevel define o nível dos eventos com erro. O padrão é slog.LevelError.
+func SlogErrorLevel(level slog.Level) SlogOption {
+	return func(h *slogHook) {
+		h.errorLevel = level
+	}
+}
+
+// SlogSlowQuery registra no nível informado, com o atributo `slow=true`, as execuções que levarem threshold ou mais.
+func SlogSlowQuery(threshold time.Duration, level slog.Level) SlogOption {
+	return func(h *slogHook) {
+		h.slowThreshold = threshold
+		h.slowLevel = level
+	}
+}
+
+// SlogBuilds registra também os eventos de renderização (EventBuild). Por padrão somente as execuções são registradas.
+func SlogBuilds() SlogOption {
+	return func(h *slogHook) {
+		h.builds = true
+	}
+}
+
+// SlogHook retorna um Hook que registra os eventos no logger informado, ou em slog.Default() quando nil.
+//
+// Cada evento gera a mensagem "query" com os atributos kind, operation, table, sql, args, duration e, quando houver,
+// error. Os argumentos registrados já passaram pela política de redação configurada com Redact.
+//
+// Exemplo de uso:
+//
+//	hook := query.SlogHook(logger, query.SlogLevel(slog.LevelInfo), query.SlogSlowQuery(200*time.Millisecond, slog.LevelWarn))
+//	qb := query.NewQueryBuilder(query.SetHook(hook))
+func SlogHook(logger *slog.Logger, opts ...SlogOption) Hook {
+	h := &slogHook{logger: logger, level: slog.LevelDebug, errorLevel: slog.LevelError}
+
+	for _, item := range opts {
+		item(h)
+	}
+
+	return h
+}
+
+func (h *slogHook) OnQuery(ctx context.Context, event Event) {
+	if event.Kind == EventBuild && !h.builds {
+		return
+	}
+
+	logger := h.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	level := h.level
+	slow := event.Kind == EventExecute && h.slowThreshold != 0 && event.Duration >= h.slowThreshold
+
+	switch {
+	case event.Err != nil:
+		level = h.errorLevel
+	case slow:
+		level = h.slowLevel
+	}
+
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("kind", string(event.Kind)),
+		slog.String("operation", event.Operation),
+		slog.String("table", event.Table),
+		slog.String("sql", event.SQL),
+		slog.Any("args", event.Args),
+		slog.Duration("duration", event.Duration),
+	}
+	if slow {
+		attrs = append(attrs, slog.Bool("slow", true))
+	}
+	if event.Err != nil {
+		attrs = append(attrs, slog.String("error", event.Err.Error()))
+	}
+
+	logger.LogAttrs(ctx, level, "query", attrs...)
+}
@@ -0,0 +1,101 @@
+package query
+
+import "fmt"
+
+type deletedMode int
+
+const (
+	excludeDeleted deletedMode = iota
+	withDeleted
+	onlyDeleted
+)
+
+// SoftDelete configura a remoção lógica da tabela informada, identificada pelo preenchimento de column
+// (ex: deleted_at). Pode ser informado uma vez para cada tabela.
+//
+// Quando a tabela de From possui remoção lógica:
+//
+//   - ToSelectSql, ToSelectTotalSql e ToSelectWithTotalSql adicionam `"<alias>".<column> IS NULL`. Utilize
+//     WithDeleted para incluir os registros removidos e OnlyDeleted para retornar somente eles.
+//   - ToDeleteQuery gera um UPDATE que preenche column com o horário atual, em vez de um DELETE, somente nos
+//     registros ainda não removidos, preservando o horário da primeira remoção.
+//
+// Cada tabela de Join com remoção lógica recebe `"<Join.As>".<column> IS NULL` no ON, com o ON informado entre
+// parênteses, exceto com WithDeleted.
+//
+// Exemplo de uso:
+//
+//	qb := query.NewQueryBuilder(query.SoftDelete("users", "deleted_at")).From("users", "u")
+//
+//	sql, params := qb.ToSelectSql()
+//	// SQL: SELECT * FROM "users" AS "u" WHERE "u".deleted_at IS NULL
+//
+//	sql, params = qb.WhereAnd(query.Where{Column: "u.id", Type: "=", Val: 1}).ToDeleteQuery()
+//	// SQL: UPDATE "users" AS "u" SET deleted_at = $1 WHERE "u".deleted_at IS NULL AND ((u.id = $2))
+func SoftDelete(table string, column string) QueryBuilderConfig {
+	return func(q *QueryBuilder) {
+		softDelete := make(map[string]string, len(q.config.softDelete)+1)
+		for key, value := range q.config.softDelete {
+			softDelete[key] = value
+		}
+		softDelete[table] = column
+
+		q.config.softDelete = softDelete
+	}
+}
+
+// WithDeleted inclui os registros removidos logicamente nas queries SELECT.
+func (q *QueryBuilder) WithDeleted() *QueryBuilder {
+	q = q.builder()
+	q.deleted = withDeleted
+	return q
+}
+
+// OnlyDeleted retorna somente os registros removidos logicamente nas queries SELECT.
+func (q *QueryBuilder) OnlyDeleted() *QueryBuilder {
+	q = q.builder()
+	q.deleted = onlyDeleted
+	return q
+}
+
+func (q *QueryBuilder) softDeleteColumn() (string, bool) {
+	column, ok := q.config.softDelete[q.table]
+	return column, ok
+}
+
+// softDeleteCondition retorna a condição de remoção lógica da tabela de From, ou "" quando não se aplica.
+func (q *QueryBuilder) softDeleteCondition() string {
+	column, ok := q.softDeleteColumn()
+	if !ok {
+		return ""
+	}
+
+	switch q.deleted {
+	case withDeleted:
+		return ""
+	case onlyDeleted:
+		return fmt.Sprintf(`"%s".%s IS NOT NULL`, q.fromAlias(), column)
+	default:
+		return fmt.Sprintf(`"%s".%s IS NULL`, q.fromAlias(), column)
+	}
+}
+
+// joinSoftDeleteCondition retorna a condição de remoção lógica da tabela de Join, ou "" quando não se aplica.
+// OnlyDeleted vale somente para a tabela de From: as tabelas de Join continuam sem os registros removidos.
+func (q *QueryBuilder) joinSoftDeleteCondition(join Join) string {
+	column, ok := q.config.softDelete[join.Table]
+	if !ok || q.deleted == withDeleted {
+		return ""
+	}
+
+	return fmt.Sprintf(`"%s".%s IS NULL`, join.As, column)
+}
+
+// fromAlias retorna o alias da tabela de From ou, quando não informado, o nome da tabela.
+func (q *QueryBuilder) fromAlias() string {
+	if q.alias != "" {
+		return q.alias
+	}
+
+	return q.table
+}
@@ -0,0 +1,141 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSoftDelete(t *testing.T) {
+	softDelete := SoftDelete("users", "deleted_at")
+
+	data := []struct {
+		title  string
+		render func() (string, []interface{})
+		result string
+		args   []interface{}
+	}{
+		{
+			title:  "Test Select",
+			render: NewQueryBuilder(softDelete).From("users").ToSelectSql,
+			result: `SELECT * FROM "users" WHERE "users".deleted_at IS NULL`,
+			args:   []interface{}{},
+		},
+		{
+			title:  "Test Select Where",
+			render: NewQueryBuilder(softDelete).From("users", "u").WhereOr(Where{Column: "u.id", Type: "=", Val: 1}).ToSelectSql,
+			result: `SELECT * FROM "users" AS "u" WHERE "u".deleted_at IS NULL AND ((u.id = $1))`,
+			args:   []interface{}{1},
+		},
+		{
+			title:  "Test Count",
+			render: NewQueryBuilder(softDelete).From("users", "u").ToSelectTotalSql,
+			result: `SELECT COUNT(*) AS total FROM "users" AS "u" WHERE "u".deleted_at IS NULL`,
+			args:   []interface{}{},
+		},
+		{
+			title:  "Test With Deleted",
+			render: NewQueryBuilder(softDelete).From("users").WithDeleted().ToSelectSql,
+			result: `SELECT * FROM "users"`,
+			args:   []interface{}{},
+		},
+		{
+			title:  "Test Only Deleted",
+			render: NewQueryBuilder(softDelete).From("users").OnlyDeleted().ToSelectTotalSql,
+			result: `SELECT COUNT(*) AS total FROM "users" WHERE "users".deleted_at IS NOT NULL`,
+			args:   []interface{}{},
+		},
+		{
+			title:  "Test Other Table",
+			render: NewQueryBuilder(softDelete, SoftDelete("phones", "removed_at")).From("orders").ToSelectSql,
+			result: `SELECT * FROM "orders"`,
+			args:   []interface{}{},
+		},
+		{
+			title:  "Test Tenant",
+			render: NewQueryBuilder(softDelete, Tenant("tenant_id", 7)).From("users", "u").WhereAnd(Where{Column: "u.id", Type: "=", Val: 1}).ToSelectSql,
+			result: `SELECT * FROM "users" AS "u" WHERE "u".tenant_id = $1 AND "u".deleted_at IS NULL AND ((u.id = $2))`,
+			args:   []interface{}{7, 1},
+		},
+		{
+			title: "Test Join",
+			render: NewQueryBuilder(softDelete, SoftDelete("phones", "removed_at"), Tenant("tenant_id", 7)).From("users", "u").
+				Join(Join{Table: "phones", As: "p", On: "p.user_id = u.id", Type: LeftJoin}).
+				Join(Join{Table: "orders", As: "o", On: "o.user_id = u.id"}).
+				ToSelectSql,
+			result: `SELECT * FROM "users" AS "u" LEFT JOIN "phones" AS "p" ON (p.user_id = u.id) AND "p".tenant_id = $1 AND "p".removed_at IS NULL INNER JOIN "orders" AS "o" ON (o.user_id = u.id) AND "o".tenant_id = $2 WHERE "u".tenant_id = $3 AND "u".deleted_at IS NULL`,
+			args:   []interface{}{7, 7, 7},
+		},
+		{
+			title: "Test Join On With Or",
+			render: NewQueryBuilder(SoftDelete("phones", "removed_at")).From("users", "u").
+				Join(Join{Table: "phones", As: "p", On: "p.user_id = u.id OR p.owner_id = u.id", Type: LeftJoin}).
+				ToSelectSql,
+			result: `SELECT * FROM "users" AS "u" LEFT JOIN "phones" AS "p" ON (p.user_id = u.id OR p.owner_id = u.id) AND "p".removed_at IS NULL`,
+			args:   []interface{}{},
+		},
+		{
+			title: "Test Join With Deleted",
+			render: NewQueryBuilder(SoftDelete("phones", "removed_at")).From("users", "u").
+				Join(Join{Table: "phones", As: "p", On: "p.user_id = u.id"}).
+				WithDeleted().
+				ToSelectSql,
+			result: `SELECT * FROM "users" AS "u" INNER JOIN "phones" AS "p" ON p.user_id = u.id`,
+			args:   []interface{}{},
+		},
+		{
+			title: "Test Join Only Deleted",
+			render: NewQueryBuilder(softDelete).From("users", "u").
+				Join(Join{Table: "users", As: "m", On: "m.id = u.manager_id"}).
+				OnlyDeleted().
+				ToSelectSql,
+			result: `SELECT * FROM "users" AS "u" INNER JOIN "users" AS "m" ON (m.id = u.manager_id) AND "m".deleted_at IS NULL WHERE "u".deleted_at IS NOT NULL`,
+			args:   []interface{}{},
+		},
+		{
+			title:  "Test Update Ignores Soft Delete",
+			render: NewQueryBuilder(softDelete).From("users").Values(Value{Column: "name", Val: "Mark"}).ToUpdateQuery,
+			result: `UPDATE "users" SET name = $1`,
+			args:   []interface{}{"Mark"},
+		},
+		{
+			title:  "Test Hard Delete",
+			render: NewQueryBuilder(SoftDelete("phones", "deleted_at")).From("users").WhereAnd(Where{Column: "id", Type: "=", Val: 1}).ToDeleteQuery,
+			result: `DELETE FROM "users" WHERE (id = $1)`,
+			args:   []interface{}{1},
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.title, func(t *testing.T) {
+			query, args := item.render()
+
+			assert.Equal(t, item.result, query)
+			assert.Equal(t, item.args, args)
+
+			_, err := pg_query.Parse(query)
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("Validate Soft Delete Query", func(t *testing.T) {
+		before := time.Now()
+
+		query, args := NewQueryBuilder(softDelete, Tenant("tenant_id", 7)).
+			From("users", "u").
+			Values(Value{Column: "deleted_by", Val: 3}).
+			WhereAnd(Where{Column: "u.id", Type: "=", Val: 1}).
+			ToDeleteQuery()
+
+		assert.Equal(t, `UPDATE "users" AS "u" SET deleted_by = $1, deleted_at = $2 WHERE "u".tenant_id = $3 AND "u".deleted_at IS NULL AND ((u.id = $4))`, query)
+		require.Len(t, args, 4)
+		assert.Equal(t, []interface{}{3, 7, 1}, []interface{}{args[0], args[2], args[3]})
+
+		deletedAt, ok := args[1].(time.Time)
+		require.True(t, ok)
+		assert.WithinRange(t, deletedAt, before, time.Now())
+	})
+}
@@ -0,0 +1,156 @@
+package sqlparse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+
+	query "github.com/MMortari/go-query-builder"
+)
+
+// fragment retorna o SQL da expressão gerado pelo pg_query e a query.Expr equivalente, com os placeholders `$n`
+// substituídos por `?` e os valores correspondentes de args.
+func (p *parser) fragment(node *pg_query.Node) (string, query.Expr, error) {
+	raw, err := deparse(node)
+	if err != nil {
+		return "", query.Expr{}, err
+	}
+
+	var (
+		sql  strings.Builder
+		args []any
+	)
+
+	for i := 0; i < len(raw); {
+		switch c := raw[i]; {
+		case c == '\'' || c == '"':
+			end := skipQuoted(raw, i)
+			sql.WriteString(raw[i:end])
+			i = end
+		case c == '?':
+			sql.WriteString("??")
+			i++
+		case c == '$' && i+1 < len(raw) && isDigit(raw[i+1]):
+			end := i + 1
+			for end < len(raw) && isDigit(raw[end]) {
+				end++
+			}
+
+			n, _ := strconv.Atoi(raw[i+1 : end])
+			value, err := p.param(n)
+			if err != nil {
+				return "", query.Expr{}, err
+			}
+
+			sql.WriteByte('?')
+			args = append(args, value)
+			i = end
+		default:
+			sql.WriteByte(c)
+			i++
+		}
+	}
+
+	return raw, query.Raw(sql.String(), args...), nil
+}
+
+// value retorna o valor de uma constante ou placeholder. Retorna false para as demais expressões.
+func (p *parser) value(node *pg_query.Node) (any, bool, error) {
+	if param := node.GetParamRef(); param != nil {
+		value, err := p.param(int(param.Number))
+		return value, err == nil, err
+	}
+
+	aConst := node.GetAConst()
+	if aConst == nil || aConst.Isnull {
+		return nil, false, nil
+	}
+
+	switch v := aConst.Val.(type) {
+	case *pg_query.A_Const_Ival:
+		return int(v.Ival.Ival), true, nil
+	case *pg_query.A_Const_Fval:
+		// Inteiros fora do int32 também chegam como Fval; os demais números são mantidos como texto, sem a perda de
+		// precisão de um float64, e convertidos para numeric pelo Postgres
+		if value, err := strconv.Atoi(v.Fval.Fval); err == nil {
+			return value, true, nil
+		}
+		return v.Fval.Fval, true, nil
+	case *pg_query.A_Const_Boolval:
+		return v.Boolval.Boolval, true, nil
+	case *pg_query.A_Const_Sval:
+		return v.Sval.Sval, true, nil
+	default:
+		return nil, false, nil
+	}
+}
+func (p *parser) param(n int) (any, error) {
+	if n < 1 || n > len(p.args) {
+		return nil, fmt.Errorf("sqlparse: parameter $%d has no value, got %d args", n, len(p.args))
+	}
+
+	return p.args[n-1], nil
+}
+func (p *parser) column(node *pg_query.Node) (string, error) {
+	return deparse(node)
+}
+
+// deparse gera o SQL de uma expressão, utilizando-a como única coluna de um SELECT.
+func deparse(node *pg_query.Node) (string, error) {
+	target := node
+	if node.GetResTarget() == nil {
+		target = pg_query.MakeResTargetNodeWithVal(node, 0)
+	}
+
+	sql, err := pg_query.Deparse(&pg_query.ParseResult{Stmts: []*pg_query.RawStmt{{
+		Stmt: &pg_query.Node{Node: &pg_query.Node_SelectStmt{SelectStmt: &pg_query.SelectStmt{
+			TargetList:  []*pg_query.Node{target},
+			LimitOption: pg_query.LimitOption_LIMIT_OPTION_DEFAULT,
+			Op:          pg_query.SetOperation_SETOP_NONE,
+		}}},
+	}}})
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimPrefix(sql, "SELECT "), nil
+}
+
+func isStar(node *pg_query.Node) bool {
+	fields := node.GetResTarget().GetVal().GetColumnRef().GetFields()
+
+	return len(fields) == 1 && fields[0].GetAStar() != nil
+}
+
+// quoteIdent adiciona aspas ao nome da coluna quando necessário, como em colunas com letras maiúsculas.
+func quoteIdent(name string) string {
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '_') {
+			return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+		}
+	}
+
+	return name
+}
+
+// skipQuoted retorna a posição após a string ou identificador entre aspas iniciado em i.
+func skipQuoted(sql string, i int) int {
+	quote := sql[i]
+	for j := i + 1; j < len(sql); j++ {
+		if sql[j] != quote {
+			continue
+		}
+		if j+1 < len(sql) && sql[j+1] == quote {
+			j++
+			continue
+		}
+		return j + 1
+	}
+
+	return len(sql)
+}
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
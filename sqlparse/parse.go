@@ -0,0 +1,500 @@
+package sqlparse
+
+import (
+	"errors"
+	"fmt"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+
+	query "github.com/MMortari/go-query-builder"
+)
+
+// ErrUnsupported é retornado quando a query possui construções que não podem ser representadas no QueryBuilder,
+// como HAVING, DISTINCT, CTEs ou UNION.
+var ErrUnsupported = errors.New("sqlparse: unsupported construct")
+
+// ParseSelect converte um SELECT existente em um QueryBuilder, permitindo adicionar filtros e paginação a queries
+// legadas. args são os valores dos placeholders `$n` da query, e configs são repassadas para NewQueryBuilder.
+//
+// São convertidos: a tabela de FROM e seus JOINs (INNER, LEFT, RIGHT e FULL com ON), as colunas selecionadas, o
+// WHERE (grupos AND / OR / NOT, comparações, IN, LIKE, BETWEEN e IS NULL), GROUP BY, ORDER BY, LIMIT e OFFSET.
+// Expressões sem equivalente direto são mantidas como query.Expr. Demais construções retornam ErrUnsupported.
+//
+// Exemplo de uso:
+//
+//	qb, err := sqlparse.ParseSelect(`SELECT id, name FROM users u WHERE u.active = $1 ORDER BY name`, []any{true})
+//	if err != nil {
+//	    return err
+//	}
+//
+//	sql, params := qb.WhereAnd(query.Where{Column: "u.age", Type: ">", Val: 18}).PaginationPaged(1, 20).ToSelectSql()
+//	// SQL: SELECT id, name FROM "users" AS "u" WHERE (u.active = $1) AND (u.age > $2) ORDER BY name LIMIT 20 OFFSET 0
+func ParseSelect(sql string, args []any, configs ...query.QueryBuilderConfig) (*query.QueryBuilder, error) {
+	stmt, err := parse(sql)
+	if err != nil {
+		return nil, err
+	}
+
+	selectStmt := stmt.GetSelectStmt()
+	if selectStmt == nil {
+		return nil, fmt.Errorf("%w: expected a SELECT statement", ErrUnsupported)
+	}
+
+	p := parser{args: args, qb: query.NewQueryBuilder(configs...)}
+	if err := p.selectStmt(selectStmt); err != nil {
+		return nil, err
+	}
+
+	return p.qb, nil
+}
+
+// ParseUpdate converte um UPDATE existente em um QueryBuilder, com os valores de SET em Values e o WHERE nas
+// mesmas regras de ParseSelect. UPDATE com FROM, RETURNING ou CTEs retorna ErrUnsupported.
+//
+// Exemplo de uso:
+//
+//	qb, err := sqlparse.ParseUpdate(`UPDATE users SET name = $1, updated_at = now() WHERE id = $2`, []any{"Mark", 7})
+//
+//	sql, params := qb.ToUpdateQuery()
+//	// SQL: UPDATE "users" SET name = $1, updated_at = now() WHERE (id = $2)
+func ParseUpdate(sql string, args []any, configs ...query.QueryBuilderConfig) (*query.QueryBuilder, error) {
+	stmt, err := parse(sql)
+	if err != nil {
+		return nil, err
+	}
+
+	updateStmt := stmt.GetUpdateStmt()
+	if updateStmt == nil {
+		return nil, fmt.Errorf("%w: expected an UPDATE statement", ErrUnsupported)
+	}
+
+	p := parser{args: args, qb: query.NewQueryBuilder(configs...)}
+	if err := p.updateStmt(updateStmt); err != nil {
+		return nil, err
+	}
+
+	return p.qb, nil
+}
+
+func parse(sql string) (*pg_query.Node, error) {
+	tree, err := pg_query.Parse(sql)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tree.Stmts) != 1 {
+		return nil, fmt.Errorf("%w: expected a single statement, got %d", ErrUnsupported, len(tree.Stmts))
+	}
+
+	return tree.Stmts[0].Stmt, nil
+}
+
+type parser struct {
+	args []any
+	qb   *query.QueryBuilder
+}
+
+func (p *parser) selectStmt(stmt *pg_query.SelectStmt) error {
+	switch {
+	case stmt.Op != pg_query.SetOperation_SETOP_NONE:
+		return unsupported("UNION / INTERSECT / EXCEPT")
+	case stmt.WithClause != nil:
+		return unsupported("WITH")
+	case len(stmt.DistinctClause) != 0:
+		return unsupported("DISTINCT")
+	case stmt.IntoClause != nil:
+		return unsupported("SELECT INTO")
+	case stmt.HavingClause != nil:
+		return unsupported("HAVING")
+	case len(stmt.WindowClause) != 0:
+		return unsupported("WINDOW")
+	case len(stmt.LockingClause) != 0:
+		return unsupported("FOR UPDATE / FOR SHARE")
+	case len(stmt.ValuesLists) != 0:
+		return unsupported("VALUES")
+	case stmt.LimitOption == pg_query.LimitOption_LIMIT_OPTION_WITH_TIES:
+		return unsupported("FETCH WITH TIES")
+	case len(stmt.FromClause) != 1:
+		return unsupported("SELECT without a single FROM item")
+	}
+
+	if err := p.from(stmt.FromClause[0]); err != nil {
+		return err
+	}
+
+	// SELECT * é o padrão do builder
+	if len(stmt.TargetList) != 1 || !isStar(stmt.TargetList[0]) {
+		for _, item := range stmt.TargetList {
+			raw, expr, err := p.fragment(item)
+			if err != nil {
+				return err
+			}
+			if len(expr.Args) == 0 {
+				p.qb = p.qb.Select(raw)
+			} else {
+				p.qb = p.qb.SelectExpr(expr)
+			}
+		}
+	}
+
+	if err := p.where(stmt.WhereClause); err != nil {
+		return err
+	}
+
+	for _, item := range stmt.GroupClause {
+		raw, expr, err := p.fragment(item)
+		if err != nil {
+			return err
+		}
+		if len(expr.Args) == 0 {
+			p.qb = p.qb.GroupBy(raw)
+		} else {
+			p.qb = p.qb.GroupByExpr(expr)
+		}
+	}
+
+	for _, item := range stmt.SortClause {
+		if err := p.orderBy(item.GetSortBy()); err != nil {
+			return err
+		}
+	}
+
+	if stmt.LimitCount != nil {
+		limit, err := p.int(stmt.LimitCount, "LIMIT")
+		if err != nil {
+			return err
+		}
+		if limit != nil {
+			p.qb = p.qb.Limit(*limit)
+		}
+	}
+	if stmt.LimitOffset != nil {
+		offset, err := p.int(stmt.LimitOffset, "OFFSET")
+		if err != nil {
+			return err
+		}
+		if offset != nil {
+			p.qb = p.qb.Offset(*offset)
+		}
+	}
+
+	return nil
+}
+
+func (p *parser) updateStmt(stmt *pg_query.UpdateStmt) error {
+	switch {
+	case stmt.WithClause != nil:
+		return unsupported("WITH")
+	case len(stmt.FromClause) != 0:
+		return unsupported("UPDATE ... FROM")
+	case len(stmt.ReturningList) != 0:
+		return unsupported("RETURNING")
+	}
+
+	if err := p.table(stmt.Relation); err != nil {
+		return err
+	}
+
+	for _, item := range stmt.TargetList {
+		target := item.GetResTarget()
+		if target == nil || len(target.Indirection) != 0 || target.Val.GetMultiAssignRef() != nil {
+			return unsupported("SET with indirection or multiple columns")
+		}
+
+		if value, ok, err := p.value(target.Val); err != nil {
+			return err
+		} else if ok {
+			p.qb = p.qb.Values(query.Value{Column: quoteIdent(target.Name), Val: value})
+			continue
+		}
+
+		_, expr, err := p.fragment(target.Val)
+		if err != nil {
+			return err
+		}
+		p.qb = p.qb.Values(query.Value{Column: quoteIdent(target.Name), Val: expr})
+	}
+
+	return p.where(stmt.WhereClause)
+}
+
+// from converte a tabela de FROM e os JOINs, percorrendo a árvore de JoinExpr da esquerda para a direita.
+func (p *parser) from(node *pg_query.Node) error {
+	if relation := node.GetRangeVar(); relation != nil {
+		return p.table(relation)
+	}
+
+	join := node.GetJoinExpr()
+	if join == nil {
+		return unsupported("subquery or function in FROM")
+	}
+	if join.IsNatural || len(join.UsingClause) != 0 || join.Quals == nil {
+		return unsupported("JOIN without ON")
+	}
+	if join.Alias != nil {
+		return unsupported("JOIN alias")
+	}
+
+	if err := p.from(join.Larg); err != nil {
+		return err
+	}
+
+	relation := join.Rarg.GetRangeVar()
+	if relation == nil {
+		return unsupported("nested JOIN or subquery in JOIN")
+	}
+	if err := checkTable(relation); err != nil {
+		return err
+	}
+
+	var joinType query.JoinType
+	switch join.Jointype {
+	case pg_query.JoinType_JOIN_INNER:
+		joinType = query.InnerJoin
+	case pg_query.JoinType_JOIN_LEFT:
+		joinType = query.LeftJoin
+	case pg_query.JoinType_JOIN_RIGHT:
+		joinType = query.RightJoin
+	case pg_query.JoinType_JOIN_FULL:
+		joinType = query.FullJoin
+	default:
+		return unsupported(join.Jointype.String())
+	}
+
+	on, expr, err := p.fragment(join.Quals)
+	if err != nil {
+		return err
+	}
+	if len(expr.Args) != 0 {
+		return unsupported("parameters in JOIN ON")
+	}
+
+	as := relation.Relname
+	if relation.Alias != nil {
+		as = relation.Alias.Aliasname
+	}
+
+	p.qb = p.qb.Join(query.Join{Table: relation.Relname, As: as, On: on, Type: joinType})
+
+	return nil
+}
+func (p *parser) table(relation *pg_query.RangeVar) error {
+	if err := checkTable(relation); err != nil {
+		return err
+	}
+
+	if relation.Alias != nil {
+		p.qb = p.qb.From(relation.Relname, relation.Alias.Aliasname)
+	} else {
+		p.qb = p.qb.From(relation.Relname)
+	}
+
+	return nil
+}
+
+// checkTable retorna ErrUnsupported para as tabelas de FROM e JOIN sem equivalente no builder.
+func checkTable(relation *pg_query.RangeVar) error {
+	switch {
+	case relation.Schemaname != "":
+		return unsupported("schema-qualified table")
+	case relation.Alias != nil && len(relation.Alias.Colnames) != 0:
+		return unsupported("table alias with column names")
+	case !relation.Inh:
+		return unsupported("ONLY")
+	}
+
+	return nil
+}
+
+// where adiciona o WHERE com WhereAnd, separando as condições de um AND no nível principal.
+func (p *parser) where(node *pg_query.Node) error {
+	if node == nil {
+		return nil
+	}
+
+	where, err := p.condition(node)
+	if err != nil {
+		return err
+	}
+
+	if len(where.And) != 0 && len(where.Or) == 0 && !where.Not {
+		p.qb = p.qb.WhereAnd(where.And...)
+	} else {
+		p.qb = p.qb.WhereAnd(where)
+	}
+
+	return nil
+}
+func (p *parser) condition(node *pg_query.Node) (query.Where, error) {
+	if boolExpr := node.GetBoolExpr(); boolExpr != nil {
+		wheres := make([]query.Where, 0, len(boolExpr.Args))
+		for _, item := range boolExpr.Args {
+			where, err := p.condition(item)
+			if err != nil {
+				return query.Where{}, err
+			}
+			wheres = append(wheres, where)
+		}
+
+		switch boolExpr.Boolop {
+		case pg_query.BoolExprType_AND_EXPR:
+			return query.Where{And: wheres}, nil
+		case pg_query.BoolExprType_OR_EXPR:
+			return query.Where{Or: wheres}, nil
+		case pg_query.BoolExprType_NOT_EXPR:
+			where := wheres[0]
+			where.Not = !where.Not
+			return where, nil
+		}
+	}
+
+	if where, ok, err := p.simpleCondition(node); err != nil || ok {
+		return where, err
+	}
+
+	_, expr, err := p.fragment(node)
+	if err != nil {
+		return query.Where{}, err
+	}
+
+	return query.Where{ColumnExpr: expr}, nil
+}
+
+// simpleCondition converte comparações entre uma coluna e valores nos campos Column / Type / Val. Retorna false
+// para as demais expressões, que são mantidas como query.Expr.
+func (p *parser) simpleCondition(node *pg_query.Node) (query.Where, bool, error) {
+	if nullTest := node.GetNullTest(); nullTest != nil && nullTest.Arg.GetColumnRef() != nil {
+		column, err := p.column(nullTest.Arg)
+		if err != nil {
+			return query.Where{}, false, err
+		}
+
+		if nullTest.Nulltesttype == pg_query.NullTestType_IS_NOT_NULL {
+			return query.Where{Column: column, Type: "IS NOT NULL"}, true, nil
+		}
+		return query.Where{Column: column, Type: "IS NULL"}, true, nil
+	}
+
+	aExpr := node.GetAExpr()
+	if aExpr == nil || aExpr.Lexpr.GetColumnRef() == nil || len(aExpr.Name) != 1 {
+		return query.Where{}, false, nil
+	}
+
+	var (
+		Type     string
+		multiple bool
+	)
+
+	operator := aExpr.Name[0].GetString_().GetSval()
+	switch aExpr.Kind {
+	case pg_query.A_Expr_Kind_AEXPR_OP:
+		Type = operator
+	case pg_query.A_Expr_Kind_AEXPR_IN:
+		Type, multiple = map[string]string{"=": "IN", "<>": "NOT IN"}[operator], true
+	case pg_query.A_Expr_Kind_AEXPR_LIKE:
+		Type = map[string]string{"~~": "LIKE", "!~~": "NOT LIKE"}[operator]
+	case pg_query.A_Expr_Kind_AEXPR_ILIKE:
+		Type = map[string]string{"~~*": "ILIKE", "!~~*": "NOT ILIKE"}[operator]
+	case pg_query.A_Expr_Kind_AEXPR_BETWEEN:
+		Type, multiple = "BETWEEN", true
+	case pg_query.A_Expr_Kind_AEXPR_NOT_BETWEEN:
+		Type, multiple = "NOT BETWEEN", true
+	}
+	if Type == "" {
+		return query.Where{}, false, nil
+	}
+
+	var val any
+	if multiple {
+		list := aExpr.Rexpr.GetList()
+		if list == nil {
+			return query.Where{}, false, nil
+		}
+
+		values := make([]any, 0, len(list.Items))
+		for _, item := range list.Items {
+			value, ok, err := p.value(item)
+			if err != nil || !ok {
+				return query.Where{}, false, err
+			}
+			values = append(values, value)
+		}
+		val = values
+	} else {
+		value, ok, err := p.value(aExpr.Rexpr)
+		if err != nil || !ok {
+			return query.Where{}, false, err
+		}
+		val = value
+	}
+
+	column, err := p.column(aExpr.Lexpr)
+	if err != nil {
+		return query.Where{}, false, err
+	}
+
+	return query.Where{Column: column, Type: Type, Val: val}, true, nil
+}
+
+func (p *parser) orderBy(sortBy *pg_query.SortBy) error {
+	switch {
+	case sortBy == nil:
+		return unsupported("ORDER BY item")
+	case sortBy.SortbyDir == pg_query.SortByDir_SORTBY_USING:
+		return unsupported("ORDER BY USING")
+	case sortBy.SortbyNulls == pg_query.SortByNulls_SORTBY_NULLS_FIRST || sortBy.SortbyNulls == pg_query.SortByNulls_SORTBY_NULLS_LAST:
+		return unsupported("NULLS FIRST / NULLS LAST")
+	}
+
+	orderBy := query.OrderBy{}
+	switch sortBy.SortbyDir {
+	case pg_query.SortByDir_SORTBY_ASC:
+		orderBy.Type = "ASC"
+	case pg_query.SortByDir_SORTBY_DESC:
+		orderBy.Type = "DESC"
+	}
+
+	raw, expr, err := p.fragment(sortBy.Node)
+	if err != nil {
+		return err
+	}
+	if len(expr.Args) == 0 {
+		orderBy.Column = raw
+	} else {
+		orderBy.ColumnExpr = expr
+	}
+
+	p.qb = p.qb.OrderBy(orderBy)
+
+	return nil
+}
+
+// int retorna o valor de LIMIT / OFFSET. Retorna nil para `LIMIT ALL` / `LIMIT NULL`.
+func (p *parser) int(node *pg_query.Node, clause string) (*int, error) {
+	if aConst := node.GetAConst(); aConst != nil && aConst.Isnull {
+		return nil, nil
+	}
+
+	value, ok, err := p.value(node)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := value.(type) {
+	case int:
+		if ok {
+			return &v, nil
+		}
+	case int64:
+		if ok {
+			n := int(v)
+			return &n, nil
+		}
+	}
+
+	return nil, unsupported(clause + " with a non-integer value")
+}
+
+func unsupported(construct string) error {
+	return fmt.Errorf("%w: %s", ErrUnsupported, construct)
+}
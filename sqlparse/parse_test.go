@@ -0,0 +1,175 @@
+package sqlparse
+
+import (
+	"testing"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	query "github.com/MMortari/go-query-builder"
+)
+
+func TestParseSelect(t *testing.T) {
+	data := []struct {
+		title  string
+		sql    string
+		args   []any
+		result string
+		params []interface{}
+	}{
+		{
+			title:  "Test Simple",
+			sql:    `SELECT * FROM users`,
+			result: `SELECT * FROM "users"`,
+			params: []interface{}{},
+		},
+		{
+			title:  "Test Columns And Alias",
+			sql:    `SELECT u.id, u.name AS nome, "Email" FROM "Users" AS u`,
+			result: `SELECT u.id, u.name AS nome, "Email" FROM "Users" AS "u"`,
+			params: []interface{}{},
+		},
+		{
+			title:  "Test Joins",
+			sql:    `SELECT u.id FROM users u LEFT JOIN phones p ON p.user_id = u.id JOIN tenants ON tenants.id = u.tenant_id`,
+			result: `SELECT u.id FROM "users" AS "u" LEFT JOIN "phones" AS "p" ON p.user_id = u.id INNER JOIN "tenants" AS "tenants" ON tenants.id = u.tenant_id`,
+			params: []interface{}{},
+		},
+		{
+			title:  "Test Where Groups",
+			sql:    `SELECT * FROM users WHERE active = $1 AND (age > 18 OR name ILIKE 'M%') AND NOT id IN (1, 2) AND deleted_at IS NULL AND age BETWEEN $2 AND 60`,
+			args:   []any{true, 18},
+			result: `SELECT * FROM "users" WHERE (active = $1 AND (age > $2 OR name ILIKE $3) AND NOT (id IN ($4, $5)) AND deleted_at IS NULL AND age BETWEEN $6 AND $7)`,
+			params: []interface{}{true, 18, "M%", 1, 2, 18, 60},
+		},
+		{
+			title:  "Test Where Single Or",
+			sql:    `SELECT * FROM users WHERE status = 'a' OR status = 'b'`,
+			result: `SELECT * FROM "users" WHERE ((status = $1 OR status = $2))`,
+			params: []interface{}{"a", "b"},
+		},
+		{
+			title:  "Test Expressions",
+			sql:    `SELECT id, similarity(name, $1) AS score FROM users WHERE lower(email) = $2 AND created_at > now() - $3::interval AND note <> '?' GROUP BY date_trunc($4, created_at), id ORDER BY similarity(name, $1) DESC`,
+			args:   []any{"mark", "m@x.com", "1 day", "month"},
+			result: `SELECT id, similarity(name, $1) AS score FROM "users" WHERE (lower(email) = $2 AND created_at > (now() - $3::interval) AND note <> $4) GROUP BY date_trunc($5, created_at), id ORDER BY similarity(name, $6) DESC`,
+			params: []interface{}{"mark", "m@x.com", "1 day", "?", "month", "mark"},
+		},
+		{
+			title:  "Test Question Mark Literal",
+			sql:    `SELECT 'a?' AS x, data ? 'key' AS has_key FROM users`,
+			result: `SELECT 'a?' AS x, data ? 'key' AS has_key FROM "users"`,
+			params: []interface{}{},
+		},
+		{
+			title:  "Test Numeric Constants",
+			sql:    `SELECT * FROM users WHERE id = 9007199254740993 AND balance > 12345678901234567890.12 AND score >= 1.5`,
+			result: `SELECT * FROM "users" WHERE (id = $1 AND balance > $2 AND score >= $3)`,
+			params: []interface{}{9007199254740993, "12345678901234567890.12", "1.5"},
+		},
+		{
+			title:  "Test Order And Pagination",
+			sql:    `SELECT * FROM users ORDER BY name, id DESC LIMIT $1 OFFSET 20`,
+			args:   []any{10},
+			result: `SELECT * FROM "users" ORDER BY name, id DESC LIMIT 10 OFFSET 20`,
+			params: []interface{}{},
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.title, func(t *testing.T) {
+			qb, err := ParseSelect(item.sql, item.args)
+			require.NoError(t, err)
+
+			sql, params := qb.ToSelectSql()
+			require.NoError(t, qb.Err())
+
+			assert.Equal(t, item.result, sql)
+			assert.Equal(t, item.params, params)
+
+			_, err = pg_query.Parse(sql)
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("Validate Augment Legacy Query", func(t *testing.T) {
+		qb, err := ParseSelect(`SELECT u.id, u.name FROM users u WHERE u.active = $1 OR u.admin = $2 ORDER BY u.name`, []any{true, true}, query.Tenant("tenant_id", 7))
+		require.NoError(t, err)
+
+		sql, params := qb.WhereAnd(query.Where{Column: "u.age", Type: ">", Val: 18}).PaginationPaged(2, 20).ToSelectSql()
+
+		assert.Equal(t, `SELECT u.id, u.name FROM "users" AS "u" WHERE "u".tenant_id = $1 AND (((u.active = $2 OR u.admin = $3)) AND (u.age > $4)) ORDER BY u.name LIMIT 20 OFFSET 20`, sql)
+		assert.Equal(t, []interface{}{7, true, true, 18}, params)
+	})
+
+	t.Run("Validate Immutable", func(t *testing.T) {
+		qb, err := ParseSelect(`SELECT id FROM users u JOIN phones p ON p.user_id = u.id WHERE u.active = $1 GROUP BY id ORDER BY name LIMIT 5 OFFSET 10`, []any{true}, query.Immutable())
+		require.NoError(t, err)
+
+		sql, params := qb.ToSelectSql()
+		require.NoError(t, qb.Err())
+
+		assert.Equal(t, `SELECT id FROM "users" AS "u" INNER JOIN "phones" AS "p" ON p.user_id = u.id WHERE (u.active = $1) GROUP BY id ORDER BY name LIMIT 5 OFFSET 10`, sql)
+		assert.Equal(t, []interface{}{true}, params)
+	})
+
+	t.Run("Validate Unsupported", func(t *testing.T) {
+		for _, sql := range []string{
+			`SELECT DISTINCT name FROM users`,
+			`SELECT name, count(*) FROM users GROUP BY name HAVING count(*) > 1`,
+			`WITH a AS (SELECT 1) SELECT * FROM a`,
+			`SELECT id FROM users UNION SELECT id FROM admins`,
+			`SELECT * FROM users, phones`,
+			`SELECT * FROM (SELECT * FROM users) u`,
+			`SELECT * FROM public.users`,
+			`SELECT * FROM users JOIN phones USING (user_id)`,
+			`SELECT * FROM users u JOIN ONLY phones p ON p.user_id = u.id`,
+			`SELECT * FROM users u JOIN phones p (a, b) ON p.a = u.id`,
+			`SELECT * FROM users u JOIN public.phones p ON p.user_id = u.id`,
+			`SELECT * FROM users u JOIN phones p ON p.user_id = u.id AND p.kind = $1`,
+			`SELECT * FROM users ORDER BY name NULLS LAST`,
+			`SELECT * FROM users FOR UPDATE`,
+			`UPDATE users SET name = 'x'`,
+		} {
+			_, err := ParseSelect(sql, []any{"x"})
+			assert.ErrorIs(t, err, ErrUnsupported, sql)
+		}
+	})
+
+	t.Run("Validate Invalid", func(t *testing.T) {
+		_, err := ParseSelect(`SELECT * FROM`, nil)
+		assert.Error(t, err)
+
+		_, err = ParseSelect(`SELECT * FROM users WHERE id = $2`, []any{1})
+		assert.EqualError(t, err, "sqlparse: parameter $2 has no value, got 1 args")
+	})
+}
+
+func TestParseUpdate(t *testing.T) {
+	qb, err := ParseUpdate(`UPDATE users AS u SET name = $1, "Nickname" = 'm', updated_at = now(), logins = logins + $2 WHERE u.id = $3`, []any{"Mark", 1, 7})
+	require.NoError(t, err)
+
+	sql, params := qb.ToUpdateQuery()
+	require.NoError(t, qb.Err())
+
+	assert.Equal(t, `UPDATE "users" AS "u" SET name = $1, "Nickname" = $2, updated_at = now(), logins = logins + $3 WHERE (u.id = $4)`, sql)
+	assert.Equal(t, []interface{}{"Mark", "m", 1, 7}, params)
+
+	qb, err = ParseUpdate(`UPDATE users SET name = $1, updated_at = now() WHERE id = $2`, []any{"Mark", 7}, query.Immutable())
+	require.NoError(t, err)
+
+	sql, params = qb.ToUpdateQuery()
+	assert.Equal(t, `UPDATE "users" SET name = $1, updated_at = now() WHERE (id = $2)`, sql)
+	assert.Equal(t, []interface{}{"Mark", 7}, params)
+
+	for _, sql := range []string{
+		`UPDATE users SET name = 'x' FROM phones WHERE phones.user_id = users.id`,
+		`UPDATE users SET name = 'x' RETURNING id`,
+		`UPDATE users SET (name, age) = ('x', 1)`,
+		`SELECT * FROM users`,
+	} {
+		_, err := ParseUpdate(sql, nil)
+		assert.ErrorIs(t, err, ErrUnsupported, sql)
+	}
+}
@@ -0,0 +1,432 @@
+package sqlparse
+
+import (
+	"fmt"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Rule altera a árvore de uma query durante o Rewrite. Regras são aplicadas na ordem em que foram registradas, e
+// cada uma enxerga as alterações das anteriores.
+//
+// Uma regra percorre os statements com Rewrite.Statements e adiciona novos parâmetros com Rewrite.Param.
+type Rule func(r *Rewrite) error
+
+// Rewriter aplica um conjunto de regras a queries SQL arbitrárias, alterando a árvore gerada pelo pg_query e
+// gerando o SQL novamente. Um Rewriter não é alterado por Rewrite e pode ser compartilhado entre goroutines.
+//
+// Exemplo de uso:
+//
+//	rewriter := sqlparse.NewRewriter(sqlparse.Tenant("users", "tenant_id", tenantID), sqlparse.LimitCap(1000))
+//
+//	sql, args, err := rewriter.Rewrite(`SELECT * FROM users WHERE active = $1`, []any{true})
+//	// SQL: SELECT * FROM users WHERE users.tenant_id = $2 AND active = $1 LIMIT 1000
+//	// args: []any{true, tenantID}
+type Rewriter struct {
+	rules []Rule
+}
+
+func NewRewriter(rules ...Rule) *Rewriter {
+	return &Rewriter{rules: rules}
+}
+
+// With retorna um novo Rewriter com as regras de w seguidas de rules.
+func (w *Rewriter) With(rules ...Rule) *Rewriter {
+	return &Rewriter{rules: append(append([]Rule{}, w.rules...), rules...)}
+}
+
+// Rewrite aplica as regras a sql, que pode conter vários statements, e retorna o novo SQL com seus parâmetros.
+//
+// A numeração dos parâmetros existentes é mantida: `$n` continua referenciando args[n-1], e os parâmetros
+// adicionados pelas regras recebem os números seguintes a len(args), com os valores adicionados ao final dos args
+// retornados. O slice args recebido não é alterado.
+func (w *Rewriter) Rewrite(sql string, args []any) (string, []any, error) {
+	tree, err := pg_query.Parse(sql)
+	if err != nil {
+		return "", nil, err
+	}
+
+	r := &Rewrite{Tree: tree, args: append([]any{}, args...)}
+	if n := walkTree(tree).maxParam; n > len(args) {
+		return "", nil, fmt.Errorf("sqlparse: parameter $%d has no value, got %d args", n, len(args))
+	}
+
+	for _, rule := range w.rules {
+		if err := rule(r); err != nil {
+			return "", nil, err
+		}
+	}
+
+	result, err := pg_query.Deparse(tree)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return result, r.args, nil
+}
+
+// Rewrite é a query sendo alterada por um Rewriter.
+type Rewrite struct {
+	Tree *pg_query.ParseResult
+	args []any
+}
+
+// Statement é um SELECT, INSERT, UPDATE, DELETE ou MERGE da árvore. Somente um dos campos de statement é preenchido.
+//
+// Além dos statements principais, são retornados os statements de CTEs, subqueries e de cada lado de um UNION,
+// INTERSECT ou EXCEPT. Top indica os statements principais da query.
+type Statement struct {
+	Select *pg_query.SelectStmt
+	Insert *pg_query.InsertStmt
+	Update *pg_query.UpdateStmt
+	Delete *pg_query.DeleteStmt
+	Merge  *pg_query.MergeStmt
+	Top    bool
+
+	ctes map[string]bool
+}
+
+// IsCTE indica se name referencia uma CTE visível no statement, e não uma tabela.
+func (s Statement) IsCTE(name string) bool {
+	return s.ctes[name]
+}
+
+// Statements retorna todos os statements da árvore, na ordem em que aparecem.
+func (r *Rewrite) Statements() []Statement {
+	return walkTree(r.Tree).stmts
+}
+
+// Param adiciona value aos parâmetros da query e retorna o placeholder `$n` correspondente.
+func (r *Rewrite) Param(value any) *pg_query.Node {
+	r.args = append(r.args, value)
+	return pg_query.MakeParamRefNode(int32(len(r.args)), 0)
+}
+
+// Tenant adiciona `alias.column = $n` para cada referência a table, com value como parâmetro, seguindo as mesmas
+// regras da config query.Tenant.
+//
+// A condição é adicionada ao WHERE do statement que referencia a tabela, incluindo CTEs, subqueries e os lados de um
+// UNION, e ao WHERE de UPDATE e DELETE. Quando a tabela está no lado opcional de um LEFT ou RIGHT JOIN, a condição é
+// adicionada ao ON, mantendo as linhas sem correspondência. INSERT não é alterado.
+//
+// table pode incluir o schema (ex: "public.users"); sem ele, a tabela é identificada somente pelo nome. O nome segue
+// as regras de identificadores do SQL: sem aspas é convertido para minúsculas, então "Users" corresponde a `users`,
+// e `"Users"` somente a `"Users"`. Referências a uma CTE com o mesmo nome da tabela são ignoradas.
+//
+// Retornam ErrUnsupported, pois a condição não pode ser adicionada sem alterar o resultado ou sem permitir a
+// alteração de linhas de outro tenant: tabelas em FULL JOIN, ou no lado opcional de um JOIN com USING ou NATURAL,
+// MERGE que referencia a tabela e INSERT na tabela com ON CONFLICT DO UPDATE.
+//
+// Todas as condições utilizam o mesmo parâmetro.
+func Tenant(table, column string, value any) Rule {
+	schema, name, err := tableName(table)
+
+	return func(r *Rewrite) error {
+		if err != nil {
+			return err
+		}
+
+		t := tenantRule{schema: schema, name: name, column: column}
+		t.param = func() int32 {
+			if t.number == 0 {
+				t.number = r.Param(value).GetParamRef().Number
+			}
+			return t.number
+		}
+
+		for _, stmt := range r.Statements() {
+			var conditions []*pg_query.Node
+
+			switch {
+			case stmt.Select != nil:
+				for _, item := range stmt.Select.FromClause {
+					if err := t.from(item, stmt, &conditions); err != nil {
+						return err
+					}
+				}
+				stmt.Select.WhereClause = and(conditions, stmt.Select.WhereClause)
+			case stmt.Update != nil:
+				t.table(stmt.Update.Relation, stmt, &conditions)
+				for _, item := range stmt.Update.FromClause {
+					if err := t.from(item, stmt, &conditions); err != nil {
+						return err
+					}
+				}
+				stmt.Update.WhereClause = and(conditions, stmt.Update.WhereClause)
+			case stmt.Delete != nil:
+				t.table(stmt.Delete.Relation, stmt, &conditions)
+				for _, item := range stmt.Delete.UsingClause {
+					if err := t.from(item, stmt, &conditions); err != nil {
+						return err
+					}
+				}
+				stmt.Delete.WhereClause = and(conditions, stmt.Delete.WhereClause)
+			case stmt.Insert != nil:
+				onConflict := stmt.Insert.OnConflictClause
+				if onConflict != nil && onConflict.Action == pg_query.OnConflictAction_ONCONFLICT_UPDATE && t.matches(stmt.Insert.Relation, stmt) {
+					return fmt.Errorf("%w: tenant table %s in INSERT ... ON CONFLICT DO UPDATE", ErrUnsupported, t.name)
+				}
+			case stmt.Merge != nil:
+				if t.matches(stmt.Merge.Relation, stmt) || t.matches(stmt.Merge.SourceRelation.GetRangeVar(), stmt) {
+					return fmt.Errorf("%w: tenant table %s in MERGE", ErrUnsupported, t.name)
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+type tenantRule struct {
+	schema string
+	name   string
+	column string
+	number int32
+	param  func() int32
+}
+
+// from adiciona em conditions as condições das tabelas de um item do FROM. Itens que não são tabelas ou JOINs, como
+// subqueries, são alterados como statements próprios.
+func (t *tenantRule) from(node *pg_query.Node, stmt Statement, conditions *[]*pg_query.Node) error {
+	if rangeVar := node.GetRangeVar(); rangeVar != nil {
+		t.table(rangeVar, stmt, conditions)
+		return nil
+	}
+
+	join := node.GetJoinExpr()
+	if join == nil {
+		return nil
+	}
+
+	var on []*pg_query.Node
+	left, right := conditions, conditions
+	switch join.Jointype {
+	case pg_query.JoinType_JOIN_INNER:
+	case pg_query.JoinType_JOIN_LEFT:
+		right = &on
+	case pg_query.JoinType_JOIN_RIGHT:
+		left = &on
+	default:
+		left, right = &on, &on
+	}
+
+	if err := t.from(join.Larg, stmt, left); err != nil {
+		return err
+	}
+	if err := t.from(join.Rarg, stmt, right); err != nil {
+		return err
+	}
+
+	if len(on) == 0 {
+		return nil
+	}
+	if join.Jointype == pg_query.JoinType_JOIN_FULL || join.Quals == nil {
+		return fmt.Errorf("%w: tenant table %s in %s", ErrUnsupported, t.name, joinName(join))
+	}
+
+	join.Quals = and(on, join.Quals)
+	return nil
+}
+func (t *tenantRule) table(rangeVar *pg_query.RangeVar, stmt Statement, conditions *[]*pg_query.Node) {
+	if !t.matches(rangeVar, stmt) {
+		return
+	}
+
+	alias := rangeVar.Relname
+	if rangeVar.Alias != nil {
+		alias = rangeVar.Alias.Aliasname
+	}
+
+	*conditions = append(*conditions, pg_query.MakeAExprNode(
+		pg_query.A_Expr_Kind_AEXPR_OP,
+		[]*pg_query.Node{pg_query.MakeStrNode("=")},
+		pg_query.MakeColumnRefNode([]*pg_query.Node{pg_query.MakeStrNode(alias), pg_query.MakeStrNode(t.column)}, 0),
+		pg_query.MakeParamRefNode(t.param(), 0),
+		0,
+	))
+}
+
+// matches indica se rangeVar referencia a tabela do tenant, e não uma CTE com o mesmo nome.
+func (t *tenantRule) matches(rangeVar *pg_query.RangeVar, stmt Statement) bool {
+	switch {
+	case rangeVar == nil || rangeVar.Relname != t.name:
+		return false
+	case t.schema != "" && rangeVar.Schemaname != t.schema:
+		return false
+	default:
+		return rangeVar.Schemaname != "" || !stmt.IsCTE(rangeVar.Relname)
+	}
+}
+
+// tableName retorna o schema e o nome de table como o Postgres os interpreta, convertendo identificadores sem aspas
+// para minúsculas.
+func tableName(table string) (string, string, error) {
+	tree, err := pg_query.Parse("SELECT FROM " + table)
+	if err == nil && len(tree.Stmts) == 1 {
+		if from := tree.Stmts[0].Stmt.GetSelectStmt().GetFromClause(); len(from) == 1 {
+			if rangeVar := from[0].GetRangeVar(); rangeVar != nil && rangeVar.Inh && rangeVar.Catalogname == "" && rangeVar.Alias == nil {
+				return rangeVar.Schemaname, rangeVar.Relname, nil
+			}
+		}
+	}
+
+	return "", "", fmt.Errorf("sqlparse: invalid tenant table %q", table)
+}
+
+func joinName(join *pg_query.JoinExpr) string {
+	switch {
+	case join.Jointype == pg_query.JoinType_JOIN_FULL:
+		return "FULL JOIN"
+	case join.IsNatural:
+		return "NATURAL JOIN"
+	default:
+		return "JOIN with USING"
+	}
+}
+
+// LimitCap limita a quantidade de linhas retornadas pelos SELECTs principais da query a max. Um LIMIT maior que max,
+// ou ausente, é substituído por max, e um LIMIT com parâmetro ou expressão vira `LEAST(expr, max)`, mantendo o
+// parâmetro original. Subqueries, CTEs e os lados de um UNION não são alterados.
+func LimitCap(max int64) Rule {
+	return func(r *Rewrite) error {
+		for _, stmt := range r.Statements() {
+			if !stmt.Top || stmt.Select == nil || len(stmt.Select.ValuesLists) > 0 {
+				continue
+			}
+
+			limit := stmt.Select.LimitCount
+			switch aConst := limit.GetAConst(); {
+			case limit == nil || aConst != nil && aConst.Isnull:
+				stmt.Select.LimitCount = pg_query.MakeAConstIntNode(max, 0)
+				stmt.Select.LimitOption = pg_query.LimitOption_LIMIT_OPTION_COUNT
+			case aConst != nil && aConst.GetIval() != nil:
+				if int64(aConst.GetIval().Ival) > max {
+					stmt.Select.LimitCount = pg_query.MakeAConstIntNode(max, 0)
+				}
+			default:
+				stmt.Select.LimitCount = &pg_query.Node{Node: &pg_query.Node_MinMaxExpr{MinMaxExpr: &pg_query.MinMaxExpr{
+					Op:   pg_query.MinMaxOp_IS_LEAST,
+					Args: []*pg_query.Node{limit, pg_query.MakeAConstIntNode(max, 0)},
+				}}}
+			}
+		}
+
+		return nil
+	}
+}
+
+// and retorna conditions seguidas de where, unidas com AND.
+func and(conditions []*pg_query.Node, where *pg_query.Node) *pg_query.Node {
+	if len(conditions) == 0 {
+		return where
+	}
+
+	if boolExpr := where.GetBoolExpr(); boolExpr != nil && boolExpr.Boolop == pg_query.BoolExprType_AND_EXPR {
+		conditions = append(conditions, boolExpr.Args...)
+	} else if where != nil {
+		conditions = append(conditions, where)
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0]
+	}
+
+	return pg_query.MakeBoolExprNode(pg_query.BoolExprType_AND_EXPR, conditions, 0)
+}
+
+// walker percorre a árvore coletando os statements, com as CTEs visíveis em cada um, e o maior placeholder.
+type walker struct {
+	stmts    []Statement
+	maxParam int
+}
+
+func walkTree(tree *pg_query.ParseResult) *walker {
+	w := &walker{}
+	for _, item := range tree.Stmts {
+		w.walk(item.Stmt.ProtoReflect(), true, nil)
+	}
+
+	return w
+}
+func (w *walker) walk(msg protoreflect.Message, top bool, ctes map[string]bool) {
+	stmt := Statement{Top: top}
+	var with *pg_query.WithClause
+
+	switch node := msg.Interface().(type) {
+	case *pg_query.Node:
+		// Node somente envolve o statement, que continua sendo o principal
+		msg.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+			w.walk(value.Message(), top, ctes)
+			return true
+		})
+		return
+	case *pg_query.ParamRef:
+		w.maxParam = max(w.maxParam, int(node.Number))
+		return
+	case *pg_query.SelectStmt:
+		stmt.Select, with = node, node.WithClause
+	case *pg_query.InsertStmt:
+		stmt.Insert, with = node, node.WithClause
+	case *pg_query.UpdateStmt:
+		stmt.Update, with = node, node.WithClause
+	case *pg_query.DeleteStmt:
+		stmt.Delete, with = node, node.WithClause
+	case *pg_query.MergeStmt:
+		stmt.Merge, with = node, node.WithClause
+	default:
+		w.children(msg, ctes)
+		return
+	}
+
+	stmt.ctes = w.with(with, ctes)
+	w.stmts = append(w.stmts, stmt)
+	w.children(msg, stmt.ctes)
+}
+
+// with percorre as CTEs de um statement e retorna as CTEs visíveis no restante dele. Sem RECURSIVE, cada CTE enxerga
+// somente as declaradas antes dela.
+func (w *walker) with(with *pg_query.WithClause, ctes map[string]bool) map[string]bool {
+	if with == nil {
+		return ctes
+	}
+
+	scope := copyScope(ctes)
+	if with.Recursive {
+		for _, item := range with.Ctes {
+			scope[item.GetCommonTableExpr().Ctename] = true
+		}
+	}
+
+	for _, item := range with.Ctes {
+		cte := item.GetCommonTableExpr()
+		w.walk(cte.Ctequery.ProtoReflect(), false, scope)
+
+		scope = copyScope(scope)
+		scope[cte.Ctename] = true
+	}
+
+	return scope
+}
+func (w *walker) children(msg protoreflect.Message, ctes map[string]bool) {
+	msg.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		switch {
+		case field.Message() == nil || field.Name() == "with_clause":
+		case field.IsList():
+			for i := 0; i < value.List().Len(); i++ {
+				w.walk(value.List().Get(i).Message(), false, ctes)
+			}
+		case !field.IsMap():
+			w.walk(value.Message(), false, ctes)
+		}
+		return true
+	})
+}
+func copyScope(ctes map[string]bool) map[string]bool {
+	scope := make(map[string]bool, len(ctes)+1)
+	for name := range ctes {
+		scope[name] = true
+	}
+
+	return scope
+}
@@ -0,0 +1,216 @@
+package sqlparse
+
+import (
+	"fmt"
+	"testing"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewriter(t *testing.T) {
+	tenant := NewRewriter(Tenant("users", "tenant_id", 7))
+	limit := NewRewriter(LimitCap(100))
+
+	data := []struct {
+		title    string
+		rewriter *Rewriter
+		sql      string
+		args     []any
+		result   string
+		params   []any
+	}{
+		{
+			title:    "Test Tenant Simple",
+			rewriter: tenant,
+			sql:      `SELECT * FROM users WHERE active = $1 OR admin = $2`,
+			args:     []any{true, false},
+			result:   `SELECT * FROM users WHERE users.tenant_id = $3 AND (active = $1 OR admin = $2)`,
+			params:   []any{true, false, 7},
+		},
+		{
+			title:    "Test Tenant Joins",
+			rewriter: tenant,
+			sql:      `SELECT u.id FROM users u JOIN phones p ON p.user_id = u.id LEFT JOIN users m ON m.id = u.manager_id WHERE u.id = $1`,
+			args:     []any{1},
+			result:   `SELECT u.id FROM users u JOIN phones p ON p.user_id = u.id LEFT JOIN users m ON m.tenant_id = $2 AND m.id = u.manager_id WHERE u.tenant_id = $2 AND u.id = $1`,
+			params:   []any{1, 7},
+		},
+		{
+			title:    "Test Tenant Right Join",
+			rewriter: tenant,
+			sql:      `SELECT * FROM users u RIGHT JOIN orders o ON u.id = o.user_id`,
+			result:   `SELECT * FROM users u RIGHT JOIN orders o ON u.tenant_id = $1 AND u.id = o.user_id`,
+			params:   []any{7},
+		},
+		{
+			title:    "Test Tenant Subqueries",
+			rewriter: tenant,
+			sql:      `SELECT * FROM (SELECT id FROM users) s, orders o WHERE o.user_id IN (SELECT id FROM users WHERE active = $1) AND EXISTS (SELECT 1 FROM users x WHERE x.id = o.user_id)`,
+			args:     []any{true},
+			result:   `SELECT * FROM (SELECT id FROM users WHERE users.tenant_id = $2) s, orders o WHERE o.user_id IN (SELECT id FROM users WHERE users.tenant_id = $2 AND active = $1) AND EXISTS (SELECT 1 FROM users x WHERE x.tenant_id = $2 AND x.id = o.user_id)`,
+			params:   []any{true, 7},
+		},
+		{
+			title:    "Test Tenant CTE",
+			rewriter: tenant,
+			sql:      `WITH active AS (SELECT * FROM users WHERE active = $1) SELECT * FROM active JOIN users ON users.id = active.id`,
+			args:     []any{true},
+			result:   `WITH active AS (SELECT * FROM users WHERE users.tenant_id = $2 AND active = $1) SELECT * FROM active JOIN users ON users.id = active.id WHERE users.tenant_id = $2`,
+			params:   []any{true, 7},
+		},
+		{
+			title:    "Test Tenant CTE Shadowing Table",
+			rewriter: tenant,
+			sql:      `WITH users AS (SELECT * FROM users WHERE active) SELECT * FROM users`,
+			result:   `WITH users AS (SELECT * FROM users WHERE users.tenant_id = $1 AND active) SELECT * FROM users`,
+			params:   []any{7},
+		},
+		{
+			title:    "Test Tenant Recursive CTE",
+			rewriter: tenant,
+			sql:      `WITH RECURSIVE users AS (SELECT 1 AS id UNION ALL SELECT id + 1 FROM users WHERE id < 3) SELECT * FROM users`,
+			result:   `WITH RECURSIVE users AS (SELECT 1 AS id UNION ALL SELECT id + 1 FROM users WHERE id < 3) SELECT * FROM users`,
+			params:   []any{},
+		},
+		{
+			title:    "Test Tenant Union",
+			rewriter: tenant,
+			sql:      `SELECT id FROM users WHERE name = $1 UNION SELECT user_id FROM admins UNION ALL SELECT id FROM users`,
+			args:     []any{"Mark"},
+			result:   `(SELECT id FROM users WHERE users.tenant_id = $2 AND name = $1 UNION SELECT user_id FROM admins) UNION ALL SELECT id FROM users WHERE users.tenant_id = $2`,
+			params:   []any{"Mark", 7},
+		},
+		{
+			title:    "Test Tenant Update And Delete",
+			rewriter: tenant,
+			sql:      `UPDATE users SET name = $1 WHERE id = $2; UPDATE orders SET total = 0 FROM users u WHERE u.id = orders.user_id; DELETE FROM users u USING orders WHERE orders.user_id = u.id`,
+			args:     []any{"Mark", 1},
+			result:   `UPDATE users SET name = $1 WHERE users.tenant_id = $3 AND id = $2; UPDATE orders SET total = 0 FROM users u WHERE u.tenant_id = $3 AND u.id = orders.user_id; DELETE FROM users u USING orders WHERE u.tenant_id = $3 AND orders.user_id = u.id`,
+			params:   []any{"Mark", 1, 7},
+		},
+		{
+			title:    "Test Tenant Schema",
+			rewriter: NewRewriter(Tenant("public.users", "tenant_id", 7)),
+			sql:      `SELECT * FROM public.users pu JOIN users ON true JOIN audit.users au ON true`,
+			result:   `SELECT * FROM public.users pu JOIN users ON true JOIN audit.users au ON true WHERE pu.tenant_id = $1`,
+			params:   []any{7},
+		},
+		{
+			title:    "Test Tenant Insert",
+			rewriter: tenant,
+			sql:      `INSERT INTO users (name) VALUES ($1)`,
+			args:     []any{"Mark"},
+			result:   `INSERT INTO users (name) VALUES ($1)`,
+			params:   []any{"Mark"},
+		},
+		{
+			title:    "Test Tenant Insert On Conflict Do Nothing",
+			rewriter: tenant,
+			sql:      `INSERT INTO users (id, name) VALUES ($1, $2) ON CONFLICT (id) DO NOTHING; INSERT INTO orders (id) VALUES ($1) ON CONFLICT (id) DO UPDATE SET id = excluded.id`,
+			args:     []any{1, "Mark"},
+			result:   `INSERT INTO users (id, name) VALUES ($1, $2) ON CONFLICT (id) DO NOTHING; INSERT INTO orders (id) VALUES ($1) ON CONFLICT (id) DO UPDATE SET id = excluded.id`,
+			params:   []any{1, "Mark"},
+		},
+		{
+			title:    "Test Tenant Merge Other Table",
+			rewriter: tenant,
+			sql:      `MERGE INTO orders o USING (SELECT id FROM users) u ON o.user_id = u.id WHEN MATCHED THEN DELETE`,
+			result:   `MERGE INTO orders o USING (SELECT id FROM users WHERE users.tenant_id = $1) u ON o.user_id = u.id WHEN MATCHED THEN DELETE`,
+			params:   []any{7},
+		},
+		{
+			title:    "Test Tenant Table Name Case",
+			rewriter: NewRewriter(Tenant("Public.Users", "tenant_id", 7), Tenant(`"Orders"`, "tenant_id", 8)),
+			sql:      `SELECT * FROM public.users JOIN "Orders" o ON true JOIN orders ON true`,
+			result:   `SELECT * FROM public.users JOIN "Orders" o ON true JOIN orders ON true WHERE o.tenant_id = $2 AND users.tenant_id = $1`,
+			params:   []any{7, 8},
+		},
+		{
+			title:    "Test Limit Cap",
+			rewriter: limit,
+			sql:      `SELECT * FROM users; SELECT * FROM users LIMIT 500; SELECT * FROM users LIMIT 5; SELECT * FROM users LIMIT ALL`,
+			result:   `SELECT * FROM users LIMIT 100; SELECT * FROM users LIMIT 100; SELECT * FROM users LIMIT 5; SELECT * FROM users LIMIT 100`,
+			params:   []any{},
+		},
+		{
+			title:    "Test Limit Cap Param",
+			rewriter: limit,
+			sql:      `SELECT * FROM users WHERE id > $1 LIMIT $2`,
+			args:     []any{10, 20},
+			result:   `SELECT * FROM users WHERE id > $1 LIMIT LEAST($2, 100)`,
+			params:   []any{10, 20},
+		},
+		{
+			title:    "Test Limit Cap Only Top",
+			rewriter: limit,
+			sql:      `WITH last AS (SELECT * FROM orders LIMIT 1000) SELECT id FROM users WHERE id IN (SELECT user_id FROM last) UNION SELECT id FROM admins`,
+			result:   `WITH last AS (SELECT * FROM orders LIMIT 1000) SELECT id FROM users WHERE id IN (SELECT user_id FROM last) UNION SELECT id FROM admins LIMIT 100`,
+			params:   []any{},
+		},
+		{
+			title:    "Test Composed Rules",
+			rewriter: tenant.With(LimitCap(50), Tenant("orders", "tenant_id", 8)),
+			sql:      `SELECT * FROM users u JOIN orders o ON o.user_id = u.id WHERE u.name = $1 LIMIT $2`,
+			args:     []any{"Mark", 10},
+			result:   `SELECT * FROM users u JOIN orders o ON o.user_id = u.id WHERE o.tenant_id = $4 AND u.tenant_id = $3 AND u.name = $1 LIMIT LEAST($2, 50)`,
+			params:   []any{"Mark", 10, 7, 8},
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.title, func(t *testing.T) {
+			args := append([]any{}, item.args...)
+
+			sql, params, err := item.rewriter.Rewrite(item.sql, item.args)
+			require.NoError(t, err)
+
+			assert.Equal(t, item.result, sql)
+			assert.Equal(t, item.params, params)
+			assert.Equal(t, args, append([]any{}, item.args...))
+
+			// Todos os placeholders devem possuir um valor
+			tree, err := pg_query.Parse(sql)
+			require.NoError(t, err)
+			assert.LessOrEqual(t, walkTree(tree).maxParam, len(params))
+		})
+	}
+
+	t.Run("Validate Unsupported Joins", func(t *testing.T) {
+		for _, sql := range []string{
+			`SELECT * FROM orders FULL JOIN users ON true`,
+			`SELECT * FROM orders LEFT JOIN users USING (id)`,
+			`SELECT * FROM orders NATURAL LEFT JOIN users`,
+		} {
+			_, _, err := tenant.Rewrite(sql, nil)
+			assert.ErrorIs(t, err, ErrUnsupported, sql)
+		}
+	})
+
+	t.Run("Validate Unsupported Upserts", func(t *testing.T) {
+		for _, sql := range []string{
+			`INSERT INTO users (id, name) VALUES (1, 'Mark') ON CONFLICT (id) DO UPDATE SET name = excluded.name`,
+			`MERGE INTO users u USING orders o ON o.user_id = u.id WHEN MATCHED THEN UPDATE SET total = o.total`,
+			`MERGE INTO orders o USING users u ON o.user_id = u.id WHEN MATCHED THEN DELETE`,
+		} {
+			_, _, err := tenant.Rewrite(sql, nil)
+			assert.ErrorIs(t, err, ErrUnsupported, sql)
+		}
+	})
+
+	t.Run("Validate Invalid Table", func(t *testing.T) {
+		for _, table := range []string{"users u", "users; DROP TABLE users", "db.public.users", ""} {
+			_, _, err := NewRewriter(Tenant(table, "tenant_id", 7)).Rewrite(`SELECT * FROM users`, nil)
+			assert.EqualError(t, err, fmt.Sprintf("sqlparse: invalid tenant table %q", table))
+		}
+	})
+
+	t.Run("Validate Invalid", func(t *testing.T) {
+		_, _, err := tenant.Rewrite(`SELECT * FROM`, nil)
+		assert.Error(t, err)
+
+		_, _, err = tenant.Rewrite(`SELECT * FROM users WHERE id = $2`, []any{1})
+		assert.EqualError(t, err, "sqlparse: parameter $2 has no value, got 1 args")
+	})
+}
@@ -0,0 +1,234 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Opções aceitas na tag `db`, ex: `db:"id,pk"`.
+const (
+	// TagOmitEmpty ignora o campo em StructValues quando ele possui o valor zero.
+	TagOmitEmpty = "omitempty"
+	// TagReadOnly ignora o campo em StructValues (ex: created_at).
+	TagReadOnly = "readonly"
+	// TagPK marca a chave primária: é ignorada em StructValues e utilizada por StructPK.
+	TagPK = "pk"
+)
+
+type StructOption func(*structOptions)
+
+type structOptions struct {
+	omitEmpty bool
+}
+
+// OmitEmpty faz StructValues ignorar todos os campos com valor zero, e não apenas os marcados com omitempty.
+//
+// Útil para atualizações parciais (PATCH), onde somente os campos informados devem ser alterados.
+func OmitEmpty() StructOption {
+	return func(o *structOptions) {
+		o.omitEmpty = true
+	}
+}
+
+type structField struct {
+	column  string
+	value   reflect.Value
+	options []string
+	nested  string
+}
+
+// StructColumns retorna a lista de colunas de uma struct com tags `db`, pronta para ser utilizada em Select.
+//
+// Quando alias é informado, as colunas são prefixadas com ele. Structs aninhadas com tag representam tabelas
+// unidas pelo alias da tag (Join.As) e são selecionadas com o nome completo, ex: `p.phone AS "p.phone"`,
+// o mesmo formato lido pelo pacote executor.
+//
+// Exemplo de uso:
+//
+//	type User struct {
+//	    ID    int64  `db:"id,pk"`
+//	    Name  string `db:"name"`
+//	    Phone *Phone `db:"p"`
+//	}
+//
+//	qb := query.NewQueryBuilder().
+//	    From("users", "u").
+//	    Select(query.StructColumns(User{}, "u")...)
+//	// SELECT u.id, u.name, p.phone AS "p.phone" FROM "users" AS "u"
+func StructColumns(v any, alias string) []string {
+	columns := make([]string, 0)
+
+	for _, field := range structFields(reflect.ValueOf(v)) {
+		switch {
+		case field.nested != "":
+			columns = append(columns, fmt.Sprintf(`%s.%s AS "%s.%s"`, field.nested, field.column, field.nested, field.column))
+		case alias != "":
+			columns = append(columns, fmt.Sprintf("%s.%s", alias, field.column))
+		default:
+			columns = append(columns, field.column)
+		}
+	}
+
+	return columns
+}
+
+// StructValues retorna os valores de uma struct com tags `db`, prontos para serem utilizados em Values.
+//
+// Campos marcados com pk ou readonly, e structs aninhadas (tabelas unidas), são ignorados. Campos marcados com
+// omitempty são ignorados quando possuem valor zero; com a opção OmitEmpty() isso vale para todos os campos.
+// Ponteiros são desreferenciados, e ponteiros nulos viram NULL.
+//
+// Exemplo de uso:
+//
+//	type UserPatch struct {
+//	    ID     int64   `db:"id,pk"`
+//	    Name   *string `db:"name,omitempty"`
+//	    Active *bool   `db:"active,omitempty"`
+//	}
+//
+//	qb := query.NewQueryBuilder().
+//	    From("users").
+//	    Values(query.StructValues(patch)...).
+//	    WhereAnd(query.StructPK(patch, "")...)
+func StructValues(v any, opts ...StructOption) []Value {
+	options := structOptions{}
+	for _, item := range opts {
+		item(&options)
+	}
+
+	values := make([]Value, 0)
+
+	for _, field := range structFields(reflect.ValueOf(v)) {
+		if field.nested != "" || slices.Contains(field.options, TagPK) || slices.Contains(field.options, TagReadOnly) {
+			continue
+		}
+		if (options.omitEmpty || slices.Contains(field.options, TagOmitEmpty)) && field.value.IsZero() {
+			continue
+		}
+
+		values = append(values, Value{Column: field.column, Val: fieldValue(field.value)})
+	}
+
+	return values
+}
+
+// StructPK retorna as condições de igualdade para os campos marcados com pk, prontas para serem utilizadas em WhereAnd.
+//
+// Assim como em StructColumns, quando alias é informado as colunas são prefixadas com ele, evitando colunas ambíguas
+// em queries com JOIN.
+//
+// Exemplo de uso:
+//
+//	qb := query.NewQueryBuilder().
+//	    From("users", "u").
+//	    Join(query.Join{Table: "phones", As: "p", On: "p.user_id = u.id"}).
+//	    WhereAnd(query.StructPK(user, "u")...)
+//	// SQL: SELECT * FROM "users" AS "u" INNER JOIN "phones" AS "p" ON p.user_id = u.id WHERE (u.id = $1)
+func StructPK(v any, alias string) []Where {
+	wheres := make([]Where, 0)
+
+	for _, field := range structFields(reflect.ValueOf(v)) {
+		if field.nested != "" || !slices.Contains(field.options, TagPK) {
+			continue
+		}
+
+		column := field.column
+		if alias != "" {
+			column = fmt.Sprintf("%s.%s", alias, column)
+		}
+		wheres = append(wheres, Where{Column: column, Type: "=", Val: fieldValue(field.value)})
+	}
+
+	return wheres
+}
+
+func structFields(v reflect.Value) []structField {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			v = reflect.New(v.Type().Elem()).Elem()
+			continue
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fields := make([]structField, 0, v.NumField())
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		tag, hasTag := field.Tag.Lookup("db")
+		parts := strings.Split(tag, ",")
+		name, options := parts[0], parts[1:]
+		if name == "-" {
+			continue
+		}
+
+		value := v.Field(i)
+
+		if isNestedStruct(field.Type) {
+			if field.Anonymous && !hasTag {
+				fields = append(fields, structFields(value)...)
+				continue
+			}
+			if name == "" {
+				name = strings.ToLower(field.Name)
+			}
+
+			for _, item := range structFields(value) {
+				if item.nested == "" {
+					item.nested = name
+					fields = append(fields, item)
+				}
+			}
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		fields = append(fields, structField{column: name, value: value, options: options})
+	}
+
+	return fields
+}
+
+// isNestedStruct indica se t (ou o tipo apontado por t) é uma struct com colunas próprias, e não um valor
+// como time.Time ou sql.NullString.
+func isNestedStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == reflect.TypeFor[time.Time]() {
+		return false
+	}
+
+	pointer := reflect.PointerTo(t)
+
+	return !pointer.Implements(reflect.TypeFor[sql.Scanner]()) && !t.Implements(reflect.TypeFor[driver.Valuer]()) && !pointer.Implements(reflect.TypeFor[driver.Valuer]())
+}
+func fieldValue(v reflect.Value) any {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	return v.Interface()
+}
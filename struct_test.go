@@ -0,0 +1,91 @@
+package query
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type structPhone struct {
+	Phone string `db:"phone"`
+}
+type structBase struct {
+	ID        int64     `db:"id,pk"`
+	CreatedAt time.Time `db:"created_at,readonly"`
+}
+type structUser struct {
+	structBase
+	Name     string         `db:"name"`
+	Nickname sql.NullString `db:"nickname,omitempty"`
+	Age      *int           `db:"age,omitempty"`
+	Active   bool           `db:"active"`
+	Password string         `db:"-"`
+	Phone    *structPhone   `db:"p"`
+}
+
+func TestStruct(t *testing.T) {
+	age := 18
+	user := structUser{
+		structBase: structBase{ID: 7, CreatedAt: time.Now()},
+		Name:       "Mark",
+		Age:        &age,
+		Password:   "secret",
+	}
+
+	t.Run("Test Struct Columns", func(t *testing.T) {
+		assert.Equal(t, []string{"id", "created_at", "name", "nickname", "age", "active", `p.phone AS "p.phone"`}, StructColumns(user, ""))
+		assert.Equal(t, []string{"u.id", "u.created_at", "u.name", "u.nickname", "u.age", "u.active", `p.phone AS "p.phone"`}, StructColumns(&structUser{}, "u"))
+	})
+
+	data := []TestCase{
+		{
+			title: "Test Struct Select",
+			data: NewQueryBuilder().From("users", "u").Select(StructColumns(structUser{}, "u")...).
+				Join(Join{Table: "phones", As: "p", On: "p.user_id = u.id", Type: LeftJoin}).
+				WhereAnd(StructPK(user, "u")...),
+			result:      `SELECT u.id, u.created_at, u.name, u.nickname, u.age, u.active, p.phone AS "p.phone" FROM "users" AS "u" LEFT JOIN "phones" AS "p" ON p.user_id = u.id WHERE (u.id = $1)`,
+			resultTotal: `SELECT COUNT(*) AS total FROM "users" AS "u" LEFT JOIN "phones" AS "p" ON p.user_id = u.id WHERE (u.id = $1)`,
+			args:        []interface{}{int64(7)},
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.title, func(t *testing.T) {
+			query, args := item.data.ToSelectSql()
+
+			validateSelectQuery(t, item, query, args)
+		})
+	}
+
+	data = []TestCase{
+		{
+			title:  "Test Struct Values",
+			data:   NewQueryBuilder().From("users").Values(StructValues(user)...).WhereAnd(StructPK(&user, "")...),
+			result: `UPDATE "users" SET name = $1, age = $2, active = $3 WHERE (id = $4)`,
+			args:   []interface{}{"Mark", 18, false, int64(7)},
+		},
+		{
+			title:  "Test Struct Values Omit Empty",
+			data:   NewQueryBuilder().From("users").Values(StructValues(&user, OmitEmpty())...).WhereAnd(StructPK(user, "")...),
+			result: `UPDATE "users" SET name = $1, age = $2 WHERE (id = $3)`,
+			args:   []interface{}{"Mark", 18, int64(7)},
+		},
+		{
+			title:  "Test Struct Values Empty",
+			data:   NewQueryBuilder().From("users").Values(StructValues(structUser{}, OmitEmpty())...),
+			result: `UPDATE "users" SET `,
+			args:   nil,
+			utils:  map[string]any{"HasValues": false},
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.title, func(t *testing.T) {
+			query, args := item.data.ToUpdateQuery()
+
+			validateUpdateQuery(t, item, query, args)
+		})
+	}
+}
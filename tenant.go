@@ -0,0 +1,55 @@
+package query
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type tenantScope struct {
+	column string
+	value  any
+}
+
+// Tenant restringe todas as queries do builder ao tenant informado, adicionando `"<alias>".<column> = $n`:
+//
+//   - para a tabela de From, no WHERE de ToSelectSql, ToSelectTotalSql, ToSelectWithTotalSql, ToUpdateQuery e
+//     ToDeleteQuery, com as demais condições entre parênteses;
+//   - para cada tabela de Join, no ON, utilizando Join.As, com o ON informado entre parênteses.
+//
+// Quando From não possui alias, o nome da tabela é utilizado. Para queries que precisam acessar dados de todos os
+// tenants, utilize WithoutTenant. O span recebe o atributo db.query.tenant_scoped indicando se o filtro foi aplicado.
+//
+// Exemplo de uso:
+//
+//	qb := query.NewQueryBuilder(query.Tenant("tenant_id", tenantID)).
+//	    From("users", "u").
+//	    Join(query.Join{Table: "phones", As: "p", On: "p.user_id = u.id", Type: query.LeftJoin})
+//
+//	sql, params := qb.ToSelectSql()
+//	// SQL: SELECT * FROM "users" AS "u" LEFT JOIN "phones" AS "p" ON (p.user_id = u.id) AND "p".tenant_id = $1 WHERE "u".tenant_id = $2
+func Tenant(column string, value any) QueryBuilderConfig {
+	return func(q *QueryBuilder) {
+		q.config.tenant = &tenantScope{column: column, value: value}
+	}
+}
+
+// WithoutTenant desativa o filtro configurado com Tenant nesta query. Deve ser utilizado somente em queries que
+// precisam acessar dados de todos os tenants, como rotinas administrativas.
+func (q *QueryBuilder) WithoutTenant() *QueryBuilder {
+	q = q.builder()
+	q.withoutTenant = true
+	return q
+}
+
+func (q *QueryBuilder) tenantScoped() bool {
+	return q.config.tenant != nil && !q.withoutTenant
+}
+
+// tenantCondition retorna a condição do tenant para a tabela com o alias informado.
+func (q *QueryBuilder) tenantCondition(alias string, itemNum *int, queryData *[]interface{}, columns *[]string) string {
+	return q.equalCondition(alias, q.config.tenant.column, q.config.tenant.value, itemNum, queryData, columns)
+}
+func (q *QueryBuilder) setSpanTenant() {
+	if q.otelSpan != nil && q.config.tenant != nil {
+		q.otelSpan.SetAttributes(attribute.Bool("db.query.tenant_scoped", q.tenantScoped()))
+	}
+}
@@ -0,0 +1,131 @@
+package query
+
+import (
+	"context"
+	"testing"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTenant(t *testing.T) {
+	tenant := Tenant("tenant_id", 7)
+
+	data := []struct {
+		title  string
+		render func() (string, []interface{})
+		result string
+		args   []interface{}
+	}{
+		{
+			title:  "Test Select",
+			render: NewQueryBuilder(tenant).From("users").ToSelectSql,
+			result: `SELECT * FROM "users" WHERE "users".tenant_id = $1`,
+			args:   []interface{}{7},
+		},
+		{
+			title: "Test Select Join And Or",
+			render: NewQueryBuilder(tenant).From("users", "u").
+				Join(Join{Table: "phones", As: "p", On: "p.user_id = u.id", Type: LeftJoin}).
+				WhereAnd(Where{Column: "u.active", Type: "=", Val: true}).
+				WhereOr(Where{Column: "p.phone", Type: "IS NULL"}).
+				ToSelectSql,
+			result: `SELECT * FROM "users" AS "u" LEFT JOIN "phones" AS "p" ON (p.user_id = u.id) AND "p".tenant_id = $1 WHERE "u".tenant_id = $2 AND ((u.active = $3) OR (p.phone IS NULL))`,
+			args:   []interface{}{7, 7, true},
+		},
+		{
+			title: "Test Count",
+			render: NewQueryBuilder(tenant).From("users", "u").
+				Join(Join{Table: "phones", As: "p", On: "p.user_id = u.id"}).
+				WhereAnd(Where{Column: "u.active", Type: "=", Val: true}).
+				ToSelectTotalSql,
+			result: `SELECT COUNT(*) AS total FROM "users" AS "u" INNER JOIN "phones" AS "p" ON (p.user_id = u.id) AND "p".tenant_id = $1 WHERE "u".tenant_id = $2 AND ((u.active = $3))`,
+			args:   []interface{}{7, 7, true},
+		},
+		{
+			title: "Test Update",
+			render: NewQueryBuilder(tenant).From("users").
+				Values(Value{Column: "name", Val: "Mark"}).
+				WhereAnd(Where{Column: "id", Type: "=", Val: 1}).
+				ToUpdateQuery,
+			result: `UPDATE "users" SET name = $1 WHERE "users".tenant_id = $2 AND ((id = $3))`,
+			args:   []interface{}{"Mark", 7, 1},
+		},
+		{
+			title:  "Test Delete",
+			render: NewQueryBuilder(tenant).From("users", "u").WhereAnd(Where{Column: "u.id", Type: "=", Val: 1}).ToDeleteQuery,
+			result: `DELETE FROM "users" AS "u" WHERE "u".tenant_id = $1 AND ((u.id = $2))`,
+			args:   []interface{}{7, 1},
+		},
+		{
+			title:  "Test Without Tenant",
+			render: NewQueryBuilder(tenant).From("users").WhereAnd(Where{Column: "id", Type: "=", Val: 1}).WithoutTenant().ToSelectSql,
+			result: `SELECT * FROM "users" WHERE (id = $1)`,
+			args:   []interface{}{1},
+		},
+		{
+			title:  "Test Parse Where False",
+			render: NewQueryBuilder(Tenant("tenant_id", "acme"), ParseWhere(false)).From("users").ToSelectSql,
+			result: `SELECT * FROM "users" WHERE "users".tenant_id = 'acme'`,
+			args:   []interface{}{},
+		},
+		{
+			title:  "Test Parse Where False Escape",
+			render: NewQueryBuilder(Tenant("tenant_id", "o'brien"), ParseWhere(false)).From("users").ToSelectSql,
+			result: `SELECT * FROM "users" WHERE "users".tenant_id = 'o''brien'`,
+			args:   []interface{}{},
+		},
+		{
+			title: "Test Join On With Or",
+			render: NewQueryBuilder(tenant).From("users", "u").
+				Join(Join{Table: "phones", As: "p", On: "p.user_id = u.id OR p.owner_id = u.id"}).
+				ToSelectSql,
+			result: `SELECT * FROM "users" AS "u" INNER JOIN "phones" AS "p" ON (p.user_id = u.id OR p.owner_id = u.id) AND "p".tenant_id = $1 WHERE "u".tenant_id = $2`,
+			args:   []interface{}{7, 7},
+		},
+		{
+			title: "Test Mixed Case Alias",
+			render: NewQueryBuilder(tenant).From("users", "U").
+				Join(Join{Table: "phones", As: "Phones", On: `"Phones".user_id = "U".id`}).
+				ToSelectSql,
+			result: `SELECT * FROM "users" AS "U" INNER JOIN "phones" AS "Phones" ON ("Phones".user_id = "U".id) AND "Phones".tenant_id = $1 WHERE "U".tenant_id = $2`,
+			args:   []interface{}{7, 7},
+		},
+	}
+
+	for _, item := range data {
+		t.Run(item.title, func(t *testing.T) {
+			query, args := item.render()
+
+			assert.Equal(t, item.result, query)
+			assert.Equal(t, item.args, args)
+
+			_, err := pg_query.Parse(query)
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("Validate Otel Span Attribute", func(t *testing.T) {
+		spanRecorder := tracetest.NewSpanRecorder()
+		provider := trace.NewTracerProvider(trace.WithSpanProcessor(spanRecorder))
+
+		_, span := provider.Tracer("test-tracer").Start(context.Background(), "scoped")
+		NewQueryBuilder(tenant, SetOtelSpan(span)).From("users").ToSelectSql()
+		span.End()
+
+		_, span = provider.Tracer("test-tracer").Start(context.Background(), "unscoped")
+		NewQueryBuilder(tenant, SetOtelSpan(span)).From("users").WithoutTenant().ToSelectSql()
+		span.End()
+
+		spans := spanRecorder.Ended()
+		require.Len(t, spans, 2)
+
+		assert.Contains(t, spans[0].Attributes(), attribute.Bool("db.query.tenant_scoped", true))
+		assert.Contains(t, spans[0].Attributes(), attribute.String("db.query.parameter.users.tenant_id", "7"))
+		assert.Contains(t, spans[1].Attributes(), attribute.Bool("db.query.tenant_scoped", false))
+	})
+}
@@ -0,0 +1,77 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+	"github.com/pganalyze/pg_query_go/v6/parser"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ErrInvalidSQL é retornado pelos métodos Build* (e por Err) quando, com ValidateSQL, o builder gera um SQL inválido.
+var ErrInvalidSQL = errors.New("query: invalid SQL")
+
+// SyntaxError descreve o SQL inválido encontrado por ValidateSQL. É obtido com errors.As a partir do erro dos
+// métodos Build*, e errors.Is(err, ErrInvalidSQL) retorna true.
+type SyntaxError struct {
+	Operation string // SELECT, UPDATE ou DELETE
+	SQL       string
+	Message   string // mensagem do parser do Postgres
+	Position  int    // posição do erro em SQL, em caracteres a partir de 1, ou 0 quando desconhecida
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at position %d: %s", ErrInvalidSQL, e.Position, e.Message)
+}
+func (e *SyntaxError) Is(target error) bool {
+	return target == ErrInvalidSQL
+}
+
+// ValidateSQL faz com que cada renderização (ToSelectSql, ToSelectTotalSql, ToSelectWithTotalSql, ToUpdateQuery e
+// ToDeleteQuery) seja analisada pelo parser do Postgres, via pg_query. Quando o SQL gerado é inválido, como um
+// UPDATE sem Values, um *SyntaxError é retornado pelos métodos Build* (e por Err), registrado no span e no Hook,
+// antes da query chegar ao banco.
+//
+// A análise tem custo por renderização, então é indicada para testes, ambientes de homologação ou queries montadas
+// a partir de muitas Expr.
+//
+// Exemplo de uso:
+//
+//	qb := query.NewQueryBuilder(query.ValidateSQL()).From("users")
+//
+//	_, err := qb.BuildUpdate()
+//	var syntaxErr *query.SyntaxError
+//	if errors.As(err, &syntaxErr) {
+//	    // syntaxErr.Position: 20 (fim do SQL, após SET)
+//	}
+func ValidateSQL() QueryBuilderConfig {
+	return func(q *QueryBuilder) {
+		q.config.validate = true
+	}
+}
+
+// validate retorna err junto ao SyntaxError de query, quando configurado com ValidateSQL.
+func (q *QueryBuilder) validate(operation, query string, err error) error {
+	if !q.config.validate {
+		return err
+	}
+
+	_, parseErr := pg_query.Parse(query)
+	if parseErr == nil {
+		return err
+	}
+
+	syntaxErr := &SyntaxError{Operation: operation, SQL: query, Message: parseErr.Error()}
+	var pgErr *parser.Error
+	if errors.As(parseErr, &pgErr) {
+		syntaxErr.Message, syntaxErr.Position = pgErr.Message, pgErr.Cursorpos
+	}
+
+	if q.otelSpan != nil {
+		q.otelSpan.RecordError(syntaxErr, trace.WithAttributes(attribute.Int("db.query.error.position", syntaxErr.Position)))
+	}
+
+	return errors.Join(err, syntaxErr)
+}
@@ -0,0 +1,89 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestValidateSQL(t *testing.T) {
+	t.Run("Test Valid", func(t *testing.T) {
+		qb := NewQueryBuilder(ValidateSQL()).From("users", "u").
+			Join(Join{Table: "phones", As: "p", On: "p.user_id = u.id"}).
+			WhereAnd(Where{Column: "u.id", Type: "IN", Val: []int{1, 2}})
+
+		for _, render := range []func() (string, []interface{}){qb.ToSelectSql, qb.ToSelectTotalSql, qb.ToSelectWithTotalSql, qb.ToDeleteQuery} {
+			render()
+			assert.NoError(t, qb.Err())
+		}
+	})
+
+	t.Run("Test Empty Set", func(t *testing.T) {
+		qb := NewQueryBuilder(ValidateSQL()).From("users").WhereAnd(Where{Column: "id", Type: "=", Val: 1})
+
+		sql, _ := qb.ToUpdateQuery()
+		assert.Equal(t, `UPDATE "users" SET  WHERE (id = $1)`, sql)
+
+		var syntaxErr *SyntaxError
+		require.ErrorAs(t, qb.Err(), &syntaxErr)
+		assert.ErrorIs(t, qb.Err(), ErrInvalidSQL)
+		assert.Equal(t, &SyntaxError{Operation: "UPDATE", SQL: sql, Message: `syntax error at or near "WHERE"`, Position: 21}, syntaxErr)
+		assert.EqualError(t, syntaxErr, `query: invalid SQL at position 21: syntax error at or near "WHERE"`)
+		assert.Equal(t, "invalid_sql", buildErrorType(qb.Err()))
+	})
+
+	t.Run("Test Invalid Expr", func(t *testing.T) {
+		qb := NewQueryBuilder(ValidateSQL()).From("users").SelectExpr(Raw("count(*"))
+
+		sql, _ := qb.ToSelectSql()
+		assert.Equal(t, `SELECT count(* FROM "users"`, sql)
+
+		var syntaxErr *SyntaxError
+		require.ErrorAs(t, qb.Err(), &syntaxErr)
+		assert.Equal(t, "SELECT", syntaxErr.Operation)
+		assert.Equal(t, 16, syntaxErr.Position)
+	})
+
+	t.Run("Test Joined With Builder Errors", func(t *testing.T) {
+		qb := NewQueryBuilder(ValidateSQL(), EmptyIn(EmptyInError)).From("users").WhereAnd(Where{Column: "id", Type: "IN", Val: []int{}})
+
+		qb.ToSelectSql()
+		assert.ErrorIs(t, qb.Err(), ErrEmptyIn)
+		assert.False(t, errors.Is(qb.Err(), ErrInvalidSQL))
+
+		qb.ToUpdateQuery()
+		assert.ErrorIs(t, qb.Err(), ErrEmptyIn)
+		assert.ErrorIs(t, qb.Err(), ErrInvalidSQL)
+	})
+
+	t.Run("Test Disabled", func(t *testing.T) {
+		qb := NewQueryBuilder().From("users")
+
+		qb.ToUpdateQuery()
+		assert.NoError(t, qb.Err())
+	})
+
+	t.Run("Validate Otel Span Error", func(t *testing.T) {
+		spanRecorder := tracetest.NewSpanRecorder()
+		provider := trace.NewTracerProvider(trace.WithSpanProcessor(spanRecorder))
+
+		_, span := provider.Tracer("test-tracer").Start(context.Background(), "invalid")
+		NewQueryBuilder(ValidateSQL(), SetOtelSpan(span)).From("users").ToUpdateQuery()
+		span.End()
+
+		spans := spanRecorder.Ended()
+		require.Len(t, spans, 1)
+		require.Len(t, spans[0].Events(), 1)
+
+		event := spans[0].Events()[0]
+		assert.Equal(t, "exception", event.Name)
+		assert.Contains(t, event.Attributes, attribute.Int("db.query.error.position", 20))
+		assert.Contains(t, event.Attributes, attribute.String("exception.message", `query: invalid SQL at position 20: syntax error at end of input`))
+	})
+}